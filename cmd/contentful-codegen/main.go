@@ -0,0 +1,102 @@
+// Command contentful-codegen generates Go types from the content types of a
+// Contentful space.
+//
+// Content types are read from the Content Delivery API, the Content Management
+// API or an exported JSON file:
+//
+//	contentful-codegen -space abc123 -token $CONTENTFUL_ACCESS_TOKEN -package content -o content/types.go
+//	contentful-codegen -api management -space abc123 -environment staging -package content
+//	contentful-codegen -input export.json -package content
+//
+// The access token defaults to the CONTENTFUL_ACCESS_TOKEN environment
+// variable.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/illyabusigin/contentful/codegen"
+	"github.com/illyabusigin/contentful/delivery"
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+func main() {
+	var (
+		api         = flag.String("api", "delivery", "API to read content types from, delivery or management")
+		spaceID     = flag.String("space", "", "identifier of the space")
+		environment = flag.String("environment", "", "identifier of the environment, defaults to master")
+		token       = flag.String("token", os.Getenv("CONTENTFUL_ACCESS_TOKEN"), "access token for the API")
+		input       = flag.String("input", "", "read content types from an exported JSON file instead of the API")
+		pkg         = flag.String("package", "contentful", "name of the generated package")
+		output      = flag.String("o", "", "file to write to, defaults to stdout")
+	)
+	flag.Parse()
+
+	contentTypes, err := readContentTypes(*api, *spaceID, *environment, *token, *input)
+	if err != nil {
+		fail(err)
+	}
+
+	source := &bytes.Buffer{}
+	if err = codegen.Generate(source, contentTypes, codegen.Config{Package: *pkg}); err != nil {
+		fail(err)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(source.Bytes())
+		return
+	}
+
+	if err = ioutil.WriteFile(*output, source.Bytes(), 0644); err != nil {
+		fail(err)
+	}
+}
+
+func readContentTypes(api string, spaceID string, environment string, token string, input string) ([]*ContentType, error) {
+	if input != "" {
+		f, err := os.Open(input)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return codegen.ReadContentTypes(f)
+	}
+
+	if spaceID == "" || token == "" {
+		return nil, fmt.Errorf("either -input or -space and -token are required")
+	}
+
+	contentTypes := []*ContentType{}
+
+	switch api {
+	case "delivery":
+		client := delivery.New(token, delivery.WithEnvironment(environment))
+		it := client.IterateContentTypes(spaceID)
+		for it.Next() {
+			contentTypes = append(contentTypes, it.ContentType())
+		}
+
+		return contentTypes, it.Err()
+	case "management":
+		client := management.New(token, management.WithEnvironment(environment))
+		it := client.IterateContentTypes(spaceID, false)
+		for it.Next() {
+			contentTypes = append(contentTypes, it.ContentType())
+		}
+
+		return contentTypes, it.Err()
+	}
+
+	return nil, fmt.Errorf("unknown API %q, use delivery or management", api)
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "contentful-codegen: %v\n", err)
+	os.Exit(1)
+}
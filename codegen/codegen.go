@@ -0,0 +1,396 @@
+// Package codegen generates Go types from Contentful content types. For every
+// content type it emits a struct tagged for models.Decoder and
+// models.EncodeEntry, constants for the content type and field identifiers,
+// enum types for fields restricted by an "in" validation and helpers to decode
+// and encode entries.
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// Config configures the generated code
+type Config struct {
+	// Package is the name of the generated package
+	Package string
+}
+
+// ReadContentTypes reads content types from JSON. It accepts a list of
+// content types, an API collection response with an items list and the
+// export format of the Contentful CLI with a contentTypes list.
+func ReadContentTypes(r io.Reader) ([]*ContentType, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		contentTypes := []*ContentType{}
+		if err = json.Unmarshal(data, &contentTypes); err != nil {
+			return nil, fmt.Errorf("ReadContentTypes failed. %v", err)
+		}
+
+		return contentTypes, nil
+	}
+
+	var document struct {
+		Items        []*ContentType `json:"items"`
+		ContentTypes []*ContentType `json:"contentTypes"`
+	}
+
+	if err = json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("ReadContentTypes failed. %v", err)
+	}
+
+	return append(document.Items, document.ContentTypes...), nil
+}
+
+// Generate writes the formatted Go source for the content types to w
+func Generate(w io.Writer, contentTypes []*ContentType, config Config) error {
+	if config.Package == "" {
+		return fmt.Errorf("Generate failed. Package cannot be empty!")
+	}
+
+	g := &generator{
+		types: map[string]string{},
+		names: map[string]bool{},
+	}
+
+	sorted := make([]*ContentType, len(contentTypes))
+	copy(sorted, contentTypes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	for _, contentType := range sorted {
+		if contentType.ID == "" {
+			return fmt.Errorf("Generate failed. Content type %q has no identifier!", contentType.Name)
+		}
+
+		g.types[contentType.ID] = g.unique(goName(contentType.ID))
+	}
+
+	for _, contentType := range sorted {
+		g.contentType(contentType)
+	}
+
+	source := &bytes.Buffer{}
+	fmt.Fprintf(source, "// Code generated by contentful-codegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(source, "package %v\n\n", config.Package)
+	fmt.Fprintf(source, "import (\n")
+	if g.usesTime {
+		fmt.Fprintf(source, "\t\"time\"\n\n")
+	}
+	fmt.Fprintf(source, "\t\"github.com/illyabusigin/contentful/models\"\n)\n\n")
+	source.Write(g.buf.Bytes())
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return fmt.Errorf("Generate failed. Unable to format generated code: %v", err)
+	}
+
+	_, err = w.Write(formatted)
+	return err
+}
+
+type generator struct {
+	buf bytes.Buffer
+
+	// types maps content type identifiers to the generated type names
+	types map[string]string
+	// names contains all declared top-level identifiers
+	names    map[string]bool
+	usesTime bool
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// unique returns name, or name with a numeric suffix if it is already taken
+func (g *generator) unique(name string) string {
+	candidate := name
+	for i := 2; g.names[candidate]; i++ {
+		candidate = fmt.Sprintf("%v%v", name, i)
+	}
+
+	g.names[candidate] = true
+	return candidate
+}
+
+func (g *generator) contentType(contentType *ContentType) {
+	typeName := g.types[contentType.ID]
+	displayName := contentType.Name
+	if displayName == "" {
+		displayName = contentType.ID
+	}
+
+	constName := g.unique("ContentType" + typeName)
+	g.printf("// %v is the identifier of the %v content type\n", constName, displayName)
+	g.printf("const %v = %q\n\n", constName, contentType.ID)
+
+	fields := []Field{}
+	for _, field := range contentType.Fields {
+		if field.ID != "" && !field.Disabled && !field.Omitted {
+			fields = append(fields, field)
+		}
+	}
+
+	// Struct field names, the system properties are always present
+	fieldNames := map[string]bool{"ID": true, "Version": true, "EntryFields": true}
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		name := goName(field.ID)
+		for fieldNames[name] {
+			name += "Field"
+		}
+
+		fieldNames[name] = true
+		names[i] = name
+	}
+
+	if len(fields) > 0 {
+		g.printf("// Field identifiers of the %v content type\n", displayName)
+		g.printf("const (\n")
+		for i, field := range fields {
+			g.printf("\t%v = %q\n", g.unique(typeName+"Field"+names[i]), field.ID)
+		}
+		g.printf(")\n\n")
+	}
+
+	// Enums are declared before the struct that uses them
+	goTypes := make([]string, len(fields))
+	for i, field := range fields {
+		goTypes[i] = g.fieldType(typeName+names[i], field)
+	}
+
+	if contentType.Description != "" {
+		g.printf("// %v is an entry of the %v content type. %v\n", typeName, displayName, comment(contentType.Description))
+	} else {
+		g.printf("// %v is an entry of the %v content type\n", typeName, displayName)
+	}
+
+	g.printf("type %v struct {\n", typeName)
+	g.printf("\tID string `contentful:\"sys.id\"`\n")
+	g.printf("\tVersion int `contentful:\"sys.version\"`\n\n")
+
+	for i, field := range fields {
+		if field.Name != "" && field.Name != names[i] {
+			g.printf("\t// %v is the %v field\n", names[i], comment(field.Name))
+		}
+
+		g.printf("\t%v %v `contentful:\"%v\"`\n", names[i], goTypes[i], tag(field))
+	}
+	g.printf("}\n\n")
+
+	decodeName := g.unique("Decode" + typeName)
+	g.printf("// %v decodes the entry in the given locale, falling back to the\n", decodeName)
+	g.printf("// default locale\n")
+	g.printf("func %v(entry *models.Entry, locale string, defaultLocale string) (*%v, error) {\n", decodeName, typeName)
+	g.printf("\tv := new(%v)\n", typeName)
+	g.printf("\treturn v, models.DecodeEntry(entry, v, locale, defaultLocale)\n")
+	g.printf("}\n\n")
+
+	g.printf("// EntryFields encodes the %v into entry fields for the given locale\n", lowerFirst(typeName))
+	g.printf("func (v *%v) EntryFields(locale string) (models.EntryFields, error) {\n", typeName)
+	g.printf("\treturn models.EncodeEntry(v, locale)\n")
+	g.printf("}\n\n")
+}
+
+// fieldType returns the Go type of the field, declaring an enum type named
+// enumName if the field only permits specific values
+func (g *generator) fieldType(enumName string, field Field) string {
+	switch field.Type {
+	case ShortText, LongText, Integer, Number:
+		if values := inValues(field.Validations); len(values) > 0 {
+			return g.enum(enumName, field, field.Type, values)
+		}
+
+		return scalarType(field.Type)
+	case Boolean:
+		return "bool"
+	case Date:
+		g.usesTime = true
+		return "time.Time"
+	case Location:
+		return "*models.GeoPoint"
+	case Object:
+		return "map[string]interface{}"
+	case LinkType:
+		return g.linkType(field)
+	case Array:
+		if field.Items == nil {
+			return "[]interface{}"
+		}
+
+		items := *field.Items
+		items.ID = field.ID
+		items.Name = field.Name
+
+		return "[]" + g.fieldType(enumName, items)
+	}
+
+	return "interface{}"
+}
+
+// linkType returns a pointer to the generated type for links restricted to a
+// single known content type, the asset type for asset links and the
+// identifier of the linked entry otherwise
+func (g *generator) linkType(field Field) string {
+	if field.LinkType == "Asset" {
+		return "*models.Asset"
+	}
+
+	for _, validation := range field.Validations {
+		if len(validation.LinkContentTypes) == 1 {
+			if typeName, ok := g.types[validation.LinkContentTypes[0]]; ok {
+				return "*" + typeName
+			}
+		}
+	}
+
+	return "string"
+}
+
+func (g *generator) enum(name string, field Field, fieldType FieldType, values []interface{}) string {
+	name = g.unique(name)
+
+	g.printf("// %v are the values permitted for the %v field\n", name, comment(field.Name))
+	g.printf("type %v %v\n\n", name, scalarType(fieldType))
+	g.printf("// %v values\n", name)
+	g.printf("const (\n")
+
+	for i, value := range values {
+		constName := goName(fmt.Sprintf("%v", value))
+		if constName == "" {
+			constName = fmt.Sprintf("Value%v", i+1)
+		}
+
+		switch fieldType {
+		case ShortText, LongText:
+			g.printf("\t%v %v = %q\n", g.unique(name+constName), name, fmt.Sprintf("%v", value))
+		default:
+			g.printf("\t%v %v = %v\n", g.unique(name+constName), name, value)
+		}
+	}
+
+	g.printf(")\n\n")
+	return name
+}
+
+func scalarType(fieldType FieldType) string {
+	switch fieldType {
+	case Integer:
+		return "int"
+	case Number:
+		return "float64"
+	}
+
+	return "string"
+}
+
+// tag returns the contentful struct tag of the field
+func tag(field Field) string {
+	options := []string{field.ID}
+
+	linkType := field.LinkType
+	if field.Type == Array && field.Items != nil {
+		linkType = field.Items.LinkType
+	}
+
+	if linkType == "Entry" {
+		options = append(options, "link")
+	}
+
+	if !field.Required {
+		options = append(options, "omitempty")
+	}
+
+	return strings.Join(options, ",")
+}
+
+// inValues returns the values of the "in" validation, if any
+func inValues(validations []FieldValidation) []interface{} {
+	for _, validation := range validations {
+		if len(validation.In) > 0 {
+			return validation.In
+		}
+	}
+
+	return nil
+}
+
+// initialisms are spelled in upper case, like golint expects
+var initialisms = map[string]bool{
+	"API": true, "CSS": true, "HTML": true, "HTTP": true, "ID": true,
+	"JSON": true, "SEO": true, "SKU": true, "URL": true, "URI": true, "UUID": true,
+}
+
+// goName converts an identifier such as "blogPost", "blog-post" or
+// "image_url" into an exported Go identifier such as "BlogPost" or "ImageURL"
+func goName(s string) string {
+	words := []string{}
+	word := []rune{}
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !isLetter(r) && !isDigit(r):
+			flush()
+			continue
+		case isUpper(r) && len(word) > 0 && (!isUpper(runes[i-1]) || (i+1 < len(runes) && isLower(runes[i+1]))):
+			flush()
+		}
+
+		word = append(word, r)
+	}
+	flush()
+
+	name := ""
+	for _, w := range words {
+		if upper := strings.ToUpper(w); initialisms[upper] {
+			name += upper
+		} else {
+			name += strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+
+	if name != "" && isDigit(rune(name[0])) {
+		name = "N" + name
+	}
+
+	return name
+}
+
+func isLetter(r rune) bool { return isUpper(r) || isLower(r) }
+func isUpper(r rune) bool  { return r >= 'A' && r <= 'Z' }
+func isLower(r rune) bool  { return r >= 'a' && r <= 'z' }
+func isDigit(r rune) bool  { return r >= '0' && r <= '9' }
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// comment collapses text into a single line
+func comment(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
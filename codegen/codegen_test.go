@@ -0,0 +1,100 @@
+package codegen
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+const contentTypesJSON = `{
+	"contentTypes": [{
+		"sys": {"id": "blogPost"},
+		"name": "Blog Post",
+		"description": "A post on the blog",
+		"fields": [
+			{"id": "title", "name": "Title", "type": "Symbol", "required": true},
+			{"id": "body", "name": "Body", "type": "Text"},
+			{"id": "category", "name": "Category", "type": "Symbol", "validations": [{"in": ["news", "how-to", "2017"]}]},
+			{"id": "rating", "name": "Rating", "type": "Integer"},
+			{"id": "score", "name": "Score", "type": "Number"},
+			{"id": "featured", "name": "Featured", "type": "Boolean"},
+			{"id": "publishDate", "name": "Publish date", "type": "Date"},
+			{"id": "location", "name": "Location", "type": "Location"},
+			{"id": "metadata", "name": "Metadata", "type": "Object"},
+			{"id": "author", "name": "Author", "type": "Link", "linkType": "Entry", "validations": [{"linkContentType": ["author"]}]},
+			{"id": "related", "name": "Related", "type": "Array", "items": {"type": "Link", "linkType": "Entry"}},
+			{"id": "heroImage", "name": "Hero image", "type": "Link", "linkType": "Asset"},
+			{"id": "tags", "name": "Tags", "type": "Array", "items": {"type": "Symbol", "validations": [{"in": ["go", "cms"]}]}},
+			{"id": "id", "name": "Legacy ID", "type": "Symbol"},
+			{"id": "old", "name": "Old", "type": "Symbol", "disabled": true}
+		]
+	}, {
+		"sys": {"id": "author"},
+		"name": "Author",
+		"fields": [{"id": "name", "name": "Name", "type": "Symbol"}]
+	}]
+}`
+
+func TestGenerate(t *testing.T) {
+	contentTypes, err := ReadContentTypes(strings.NewReader(contentTypesJSON))
+	assert.Nil(t, err)
+	assert.Len(t, contentTypes, 2)
+
+	output := &bytes.Buffer{}
+	err = Generate(output, contentTypes, Config{Package: "content"})
+	assert.Nil(t, err)
+
+	source := output.String()
+	_, err = parser.ParseFile(token.NewFileSet(), "types.go", source, 0)
+	assert.Nil(t, err, "Generated code should be valid Go")
+
+	expected := []string{
+		"package content",
+		`ContentTypeBlogPost = "blogPost"`,
+		`BlogPostFieldPublishDate = "publishDate"`,
+		"type BlogPostCategory string",
+		`BlogPostCategoryHowTo BlogPostCategory = "how-to"`,
+		`BlogPostCategoryN2017 BlogPostCategory = "2017"`,
+		"Title string `contentful:\"title\"`",
+		"Body string `contentful:\"body,omitempty\"`",
+		"Category BlogPostCategory `contentful:\"category,omitempty\"`",
+		"Rating int `contentful:\"rating,omitempty\"`",
+		"Score float64 `contentful:\"score,omitempty\"`",
+		"Featured bool `contentful:\"featured,omitempty\"`",
+		"PublishDate time.Time `contentful:\"publishDate,omitempty\"`",
+		"Location *models.GeoPoint `contentful:\"location,omitempty\"`",
+		"Metadata map[string]interface{} `contentful:\"metadata,omitempty\"`",
+		"Author *Author `contentful:\"author,link,omitempty\"`",
+		"Related []string `contentful:\"related,link,omitempty\"`",
+		"HeroImage *models.Asset `contentful:\"heroImage,omitempty\"`",
+		"Tags []BlogPostTags `contentful:\"tags,omitempty\"`",
+		"IDField string `contentful:\"id,omitempty\"`",
+		"func DecodeBlogPost(entry *models.Entry, locale string, defaultLocale string) (*BlogPost, error)",
+		"func (v *BlogPost) EntryFields(locale string) (models.EntryFields, error)",
+	}
+
+	for _, snippet := range expected {
+		assert.Contains(t, strings.Join(strings.Fields(source), " "), strings.Join(strings.Fields(snippet), " "))
+	}
+
+	assert.NotContains(t, source, `"old"`, "Disabled fields should be skipped")
+}
+
+func TestGoName(t *testing.T) {
+	var names = map[string]string{
+		"blogPost":  "BlogPost",
+		"blog-post": "BlogPost",
+		"image_url": "ImageURL",
+		"seoTitle":  "SEOTitle",
+		"HTMLBody":  "HTMLBody",
+		"3d":        "N3d",
+	}
+
+	for input, expected := range names {
+		assert.Equal(t, expected, goName(input), input)
+	}
+}
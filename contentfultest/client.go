@@ -0,0 +1,122 @@
+package contentfultest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/illyabusigin/contentful/management"
+	"github.com/illyabusigin/contentful/transport"
+)
+
+// ManagementClient returns a management client using the Management and Upload
+// APIs of the server. Rate limiting and retries are disabled, opts are applied
+// after the defaults.
+func (s *Server) ManagementClient(opts ...management.Option) *management.Client {
+	defaults := []management.Option{
+		management.WithBaseURL(s.ManagementURL()),
+		management.WithUploadBaseURL(s.UploadURL()),
+		management.WithRateLimit(0, 0),
+		management.WithRetryPolicy(nil),
+	}
+
+	return management.New("token", append(defaults, opts...)...)
+}
+
+// Write is a request changing a space
+type Write struct {
+	Method string
+	// Path is relative to the space or environment, for example
+	// "content_types/post/published"
+	Path string
+	Body []byte
+}
+
+// String returns the method and path of the request
+func (w Write) String() string {
+	return w.Method + " " + w.Path
+}
+
+// Writes records the requests of a client that change a space. Add
+// Writes.Middleware to the client:
+//
+//	writes := &contentfultest.Writes{}
+//	client := server.ManagementClient(management.WithMiddleware(writes.Middleware))
+type Writes struct {
+	mu     sync.Mutex
+	writes []Write
+}
+
+// Middleware records every request that isn't a GET request
+func (w *Writes) Middleware(next transport.Doer) transport.Doer {
+	return transport.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			return next.Do(req)
+		}
+
+		write := Write{Method: req.Method, Path: spacePath(req.URL.Path)}
+		if req.Body != nil {
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			write.Body = body
+		}
+
+		w.mu.Lock()
+		w.writes = append(w.writes, write)
+		w.mu.Unlock()
+
+		return next.Do(req)
+	})
+}
+
+// All returns the requests recorded since the last Reset
+func (w *Writes) All() []Write {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]Write{}, w.writes...)
+}
+
+// Strings returns the method and path of the requests recorded since the last
+// Reset
+func (w *Writes) Strings() []string {
+	requests := []string{}
+	for _, write := range w.All() {
+		requests = append(requests, write.String())
+	}
+
+	return requests
+}
+
+// Reset forgets the recorded requests
+func (w *Writes) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.writes = nil
+}
+
+// spacePath strips the API, space and environment from the path
+func spacePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] != "spaces" {
+			continue
+		}
+
+		segments = segments[i+2:]
+		if len(segments) > 2 && segments[0] == "environments" {
+			segments = segments[2:]
+		}
+
+		break
+	}
+
+	return strings.Join(segments, "/")
+}
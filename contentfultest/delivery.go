@@ -0,0 +1,333 @@
+package contentfultest
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// maxInclude is the maximum depth of included links
+const maxInclude = 10
+
+// delivery serves the Content Delivery API, or the Content Preview API if
+// preview is set. The Delivery API only serves published entries and assets,
+// the Preview API serves the latest drafts that are not archived.
+func (s *Server) delivery(r *http.Request, preview bool, sp *space, env *environment, rest []string) (int, interface{}, error) {
+	if r.Method != http.MethodGet || len(rest) > 2 {
+		return unknownRoute()
+	}
+
+	if rest[0] == "sync" && len(rest) == 1 {
+		return s.sync(r, preview, sp, env)
+	}
+
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		locale = env.defaultLocale()
+	} else if locale != "*" && env.locale(locale) == nil {
+		return 0, nil, badRequest("Unknown locale: %v", locale)
+	}
+
+	var c *collection
+	switch rest[0] {
+	case "locales":
+		if len(rest) != 1 {
+			return unknownRoute()
+		}
+
+		docs := []map[string]interface{}{}
+		for _, l := range env.locales.all() {
+			docs = append(docs, deliveredLocale(l))
+		}
+
+		return s.query(r, docs, false)
+	case "content_types":
+		c = env.contentTypes
+	case "entries":
+		c = env.entries
+	case "assets":
+		c = env.assets
+	default:
+		return unknownRoute()
+	}
+
+	deliver := func(res *resource) map[string]interface{} {
+		snapshot := res.published
+		if preview && res.kind != kindContentType {
+			snapshot = res.doc
+			if res.archived() {
+				snapshot = nil
+			}
+		}
+
+		if snapshot == nil {
+			return nil
+		}
+
+		return s.deliver(preview, sp, env, res, snapshot, locale)
+	}
+
+	if len(rest) == 2 {
+		res := c.get(rest[1])
+		if res != nil {
+			if doc := deliver(res); doc != nil {
+				return http.StatusOK, doc, nil
+			}
+		}
+
+		return 0, nil, notFound("The resource could not be found.")
+	}
+
+	docs := []map[string]interface{}{}
+	for _, res := range c.all() {
+		if doc := deliver(res); doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+
+	status, body, err := s.query(r, docs, locale == "*")
+	if err != nil || rest[0] != "entries" {
+		return status, body, err
+	}
+
+	depth := 1
+	if include := r.URL.Query().Get("include"); include != "" {
+		depth, err = strconv.Atoi(include)
+		if err != nil || depth < 0 || depth > maxInclude {
+			return 0, nil, invalidQuery("The include parameter must be between 0 and %v.", maxInclude)
+		}
+	}
+
+	resolve := func(linkType string, id string) map[string]interface{} {
+		var res *resource
+		switch linkType {
+		case kindEntry:
+			res = env.entries.get(id)
+		case kindAsset:
+			res = env.assets.get(id)
+		}
+
+		if res == nil {
+			return nil
+		}
+
+		return deliver(res)
+	}
+
+	response := body.(map[string]interface{})
+	if includes := includes(response["items"].([]map[string]interface{}), depth, resolve); includes != nil {
+		response["includes"] = includes
+	}
+
+	return status, response, nil
+}
+
+// deliver renders a snapshot of the resource as returned by the Delivery and
+// Preview APIs. Unless all locales are requested, fields only contain the value
+// for the locale.
+func (s *Server) deliver(preview bool, sp *space, env *environment, res *resource, snapshot map[string]interface{}, locale string) map[string]interface{} {
+	doc := copyMap(snapshot)
+
+	updatedAt := res.publishedAt
+	if preview || updatedAt.IsZero() {
+		updatedAt = res.updatedAt
+	}
+
+	sys := map[string]interface{}{
+		"id":          res.id,
+		"type":        res.kind,
+		"createdAt":   timestamp(res.createdAt),
+		"updatedAt":   timestamp(updatedAt),
+		"revision":    res.publishedCounter,
+		"space":       link(kindSpace, sp.id),
+		"environment": link(kindEnvironment, env.id),
+	}
+
+	if res.contentType != "" {
+		sys["contentType"] = link(kindContentType, res.contentType)
+	}
+
+	fields := fieldsOf(doc)
+	if locale != "*" && fields != nil && res.kind != kindContentType {
+		sys["locale"] = locale
+
+		for id, value := range fields {
+			values, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if localized, ok := env.localize(values, locale); ok {
+				fields[id] = localized
+			} else {
+				delete(fields, id)
+			}
+		}
+	}
+
+	doc["sys"] = sys
+
+	return doc
+}
+
+func deliveredLocale(l *resource) map[string]interface{} {
+	return map[string]interface{}{
+		"sys":          map[string]interface{}{"id": l.id, "type": kindLocale, "version": l.version},
+		"code":         l.doc["code"],
+		"name":         l.doc["name"],
+		"default":      l.doc["default"] == true,
+		"fallbackCode": l.doc["fallbackCode"],
+	}
+}
+
+// includes resolves the entries and assets linked from the items up to the
+// given depth. Items are not included again.
+func includes(items []map[string]interface{}, depth int, resolve func(linkType string, id string) map[string]interface{}) map[string]interface{} {
+	seen := map[string]bool{}
+	for _, item := range items {
+		sys := item["sys"].(map[string]interface{})
+		seen[sys["type"].(string)+":"+sys["id"].(string)] = true
+	}
+
+	included := map[string][]map[string]interface{}{}
+	level := items
+
+	for i := 0; i < depth && len(level) > 0; i++ {
+		next := []map[string]interface{}{}
+
+		for _, item := range level {
+			for _, l := range links(item["fields"]) {
+				key := l.linkType + ":" + l.id
+				if seen[key] {
+					continue
+				}
+
+				seen[key] = true
+
+				if doc := resolve(l.linkType, l.id); doc != nil {
+					included[l.linkType] = append(included[l.linkType], doc)
+					next = append(next, doc)
+				}
+			}
+		}
+
+		level = next
+	}
+
+	if len(included) == 0 {
+		return nil
+	}
+
+	result := map[string]interface{}{}
+	for linkType, docs := range included {
+		result[linkType] = docs
+	}
+
+	return result
+}
+
+// sync serves initial and delta syncs. Sync tokens encode the sequence number
+// of the environment and the sync filters, and all items are returned on a
+// single page.
+func (s *Server) sync(r *http.Request, preview bool, sp *space, env *environment) (int, interface{}, error) {
+	q := r.URL.Query()
+	since := 0
+	initial := q.Get("initial") == "true"
+
+	if token := q.Get("sync_token"); token != "" {
+		if preview {
+			return 0, nil, badRequest("Delta syncs are not supported by the Preview API.")
+		}
+
+		data, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			return 0, nil, badRequest("Invalid sync token.")
+		}
+
+		if q, err = url.ParseQuery(string(data)); err != nil {
+			return 0, nil, badRequest("Invalid sync token.")
+		}
+
+		if since, err = strconv.Atoi(q.Get("seq")); err != nil || since > env.seq {
+			return 0, nil, badRequest("Invalid sync token.")
+		}
+
+		initial = false
+	} else if !initial {
+		return 0, nil, badRequest("Either initial or sync_token is required.")
+	}
+
+	syncType := q.Get("type")
+	if syncType == "" {
+		syncType = "all"
+	}
+
+	contentType := q.Get("content_type")
+
+	items := []interface{}{}
+	add := func(c *collection, kind string) {
+		if syncType != "all" && syncType != kind {
+			return
+		}
+
+		for _, res := range c.all() {
+			snapshot := res.published
+			if preview {
+				snapshot = res.doc
+				if res.archived() {
+					snapshot = nil
+				}
+			}
+
+			if snapshot == nil || (!initial && res.seq <= since) {
+				continue
+			}
+
+			if contentType != "" && res.contentType != contentType {
+				continue
+			}
+
+			items = append(items, s.deliver(preview, sp, env, res, snapshot, "*"))
+		}
+	}
+
+	add(env.entries, kindEntry)
+	add(env.assets, kindAsset)
+
+	if !initial {
+		for _, d := range env.deleted {
+			if d.seq <= since || (syncType != "all" && syncType != "Deletion" && syncType != d.kind) {
+				continue
+			}
+
+			items = append(items, map[string]interface{}{
+				"sys": map[string]interface{}{
+					"id":          d.id,
+					"type":        d.kind,
+					"createdAt":   timestamp(d.createdAt),
+					"updatedAt":   timestamp(d.deletedAt),
+					"deletedAt":   timestamp(d.deletedAt),
+					"space":       link(kindSpace, sp.id),
+					"environment": link(kindEnvironment, env.id),
+				},
+			})
+		}
+	}
+
+	token := url.Values{}
+	token.Set("seq", strconv.Itoa(env.seq))
+	token.Set("type", syncType)
+	if contentType != "" {
+		token.Set("content_type", contentType)
+	}
+
+	next := url.Values{}
+	next.Set("sync_token", base64.RawURLEncoding.EncodeToString([]byte(token.Encode())))
+
+	return http.StatusOK, map[string]interface{}{
+		"sys":         map[string]interface{}{"type": "Array"},
+		"items":       items,
+		"nextSyncUrl": s.URL + r.URL.Path + "?" + next.Encode(),
+	}, nil
+}
@@ -0,0 +1,760 @@
+package contentfultest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/illyabusigin/contentful/models"
+)
+
+func (s *Server) spaceCollection(r *http.Request) (int, interface{}, error) {
+	switch r.Method {
+	case http.MethodGet:
+		docs := []map[string]interface{}{}
+		for _, id := range s.spaceIDs {
+			docs = append(docs, s.spaces[id].render(nil, nil))
+		}
+
+		return s.query(r, docs, false)
+	case http.MethodPost:
+		doc, err := readDoc(r)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if err = requireName(doc); err != nil {
+			return 0, nil, err
+		}
+
+		sp := s.addSpace(s.newID(kindSpace), doc)
+		return http.StatusCreated, sp.render(nil, nil), nil
+	}
+
+	return unknownRoute()
+}
+
+func (s *Server) space(r *http.Request, api string, sp *space) (int, interface{}, error) {
+	switch api {
+	case deliveryAPI, previewAPI:
+		if r.Method != http.MethodGet {
+			return unknownRoute()
+		}
+
+		locales := []interface{}{}
+		for _, l := range sp.environments[masterEnvironment].locales.all() {
+			locales = append(locales, deliveredLocale(l))
+		}
+
+		return http.StatusOK, map[string]interface{}{
+			"sys":     map[string]interface{}{"id": sp.id, "type": kindSpace},
+			"name":    sp.doc["name"],
+			"locales": locales,
+		}, nil
+	case uploadAPI:
+		return unknownRoute()
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, sp.render(nil, nil), nil
+	case http.MethodPut:
+		if err := checkVersion(r, sp.resource, true); err != nil {
+			return 0, nil, err
+		}
+
+		doc, err := readDoc(r)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if err = requireName(doc); err != nil {
+			return 0, nil, err
+		}
+
+		s.updateDoc(sp.resource, doc)
+		return http.StatusOK, sp.render(nil, nil), nil
+	case http.MethodDelete:
+		s.removeSpace(sp.id)
+		return http.StatusNoContent, nil, nil
+	}
+
+	return unknownRoute()
+}
+
+func (s *Server) environments(r *http.Request, sp *space, rest []string) (int, interface{}, error) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			docs := []map[string]interface{}{}
+			for _, id := range sp.environmentIDs {
+				docs = append(docs, sp.environments[id].render(sp))
+			}
+
+			return s.query(r, docs, false)
+		case http.MethodPost:
+			return s.createEnvironment(r, sp, s.newID(kindEnvironment))
+		}
+
+		return unknownRoute()
+	}
+
+	env := sp.environments[rest[0]]
+	if env == nil && r.Method != http.MethodPut {
+		return 0, nil, notFound("The environment %v does not exist.", rest[0])
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, env.render(sp), nil
+	case http.MethodPut:
+		if env == nil {
+			return s.createEnvironment(r, sp, rest[0])
+		}
+
+		if err := checkVersion(r, env.resource, true); err != nil {
+			return 0, nil, err
+		}
+
+		doc, err := readDoc(r)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if err = requireName(doc); err != nil {
+			return 0, nil, err
+		}
+
+		s.updateDoc(env.resource, doc)
+		return http.StatusOK, env.render(sp), nil
+	case http.MethodDelete:
+		if env.id == masterEnvironment {
+			return 0, nil, badRequest("The master environment cannot be deleted.")
+		}
+
+		sp.removeEnvironment(env.id)
+		return http.StatusNoContent, nil, nil
+	}
+
+	return unknownRoute()
+}
+
+// createEnvironment creates an environment as a copy of the environment named
+// by the X-Contentful-Source-Environment header, or master
+func (s *Server) createEnvironment(r *http.Request, sp *space, id string) (int, interface{}, error) {
+	sourceID := r.Header.Get("X-Contentful-Source-Environment")
+	if sourceID == "" {
+		sourceID = masterEnvironment
+	}
+
+	source := sp.environments[sourceID]
+	if source == nil {
+		return 0, nil, notFound("The source environment %v does not exist.", sourceID)
+	}
+
+	doc, err := readDoc(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err = requireName(doc); err != nil {
+		return 0, nil, err
+	}
+
+	env := source.clone(s.newResource(kindEnvironment, id, doc))
+	sp.addEnvironment(env)
+
+	return http.StatusCreated, env.render(sp), nil
+}
+
+func (s *Server) management(r *http.Request, sp *space, env *environment, rest []string) (int, interface{}, error) {
+	switch rest[0] {
+	case "locales":
+		return s.locales(r, sp, env, rest[1:])
+	case "content_types":
+		return s.publishable(r, sp, env, env.contentTypes, kindContentType, rest[1:])
+	case "entries":
+		return s.publishable(r, sp, env, env.entries, kindEntry, rest[1:])
+	case "assets":
+		return s.publishable(r, sp, env, env.assets, kindAsset, rest[1:])
+	case "public":
+		if len(rest) != 2 || r.Method != http.MethodGet {
+			break
+		}
+
+		c := env.contentTypes
+		if rest[1] == "assets" {
+			c = env.assets
+		} else if rest[1] != "content_types" {
+			break
+		}
+
+		docs := []map[string]interface{}{}
+		for _, res := range c.all() {
+			if res.published != nil {
+				docs = append(docs, res.renderPublished(sp, env))
+			}
+		}
+
+		return s.query(r, docs, true)
+	}
+
+	return unknownRoute()
+}
+
+func (s *Server) locales(r *http.Request, sp *space, env *environment, rest []string) (int, interface{}, error) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			docs := []map[string]interface{}{}
+			for _, l := range env.locales.all() {
+				docs = append(docs, l.render(sp, env))
+			}
+
+			return s.query(r, docs, false)
+		case http.MethodPost:
+			doc, err := readDoc(r)
+			if err != nil {
+				return 0, nil, err
+			}
+
+			l := s.newResource(kindLocale, s.newID(kindLocale), doc)
+			if err = s.checkLocale(env, l); err != nil {
+				return 0, nil, err
+			}
+
+			env.locales.add(l)
+			return http.StatusCreated, l.render(sp, env), nil
+		}
+
+		return unknownRoute()
+	}
+
+	l := env.locales.get(rest[0])
+	if l == nil || len(rest) > 1 {
+		return 0, nil, notFound("The locale %v does not exist.", rest[0])
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, l.render(sp, env), nil
+	case http.MethodPut:
+		if err := checkVersion(r, l, true); err != nil {
+			return 0, nil, err
+		}
+
+		doc, err := readDoc(r)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		// The default locale stays the default until another locale takes over
+		if isDefault, _ := l.doc["default"].(bool); isDefault {
+			doc["default"] = true
+		}
+
+		updated := l.clone()
+		updated.doc = doc
+		if err = s.checkLocale(env, updated); err != nil {
+			return 0, nil, err
+		}
+
+		s.updateDoc(l, doc)
+		return http.StatusOK, l.render(sp, env), nil
+	case http.MethodDelete:
+		if isDefault, _ := l.doc["default"].(bool); isDefault {
+			return 0, nil, badRequest("The default locale cannot be deleted.")
+		}
+
+		env.locales.remove(l.id)
+		return http.StatusNoContent, nil, nil
+	}
+
+	return unknownRoute()
+}
+
+// checkLocale validates a new or updated locale. Making a locale the default
+// removes the default flag from the previous default locale.
+func (s *Server) checkLocale(env *environment, l *resource) error {
+	code, _ := l.doc["code"].(string)
+	if code == "" {
+		return validationFailed([]interface{}{validationDetail("required", []interface{}{"code"}, "The locale code is required.")}, "Validation error")
+	}
+
+	if _, ok := l.doc["name"].(string); !ok {
+		return validationFailed([]interface{}{validationDetail("required", []interface{}{"name"}, "The locale name is required.")}, "Validation error")
+	}
+
+	if existing := env.locale(code); existing != nil && existing.id != l.id {
+		return validationFailed([]interface{}{validationDetail("unique", []interface{}{"code"}, fmt.Sprintf("The locale %v already exists.", code))}, "Validation error")
+	}
+
+	if isDefault, _ := l.doc["default"].(bool); isDefault {
+		for _, other := range env.locales.all() {
+			if other.id != l.id {
+				delete(other.doc, "default")
+			}
+		}
+	}
+
+	return nil
+}
+
+// publishable handles content types, entries and assets, which can be
+// published
+func (s *Server) publishable(r *http.Request, sp *space, env *environment, c *collection, kind string, rest []string) (int, interface{}, error) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			docs := []map[string]interface{}{}
+			for _, res := range c.all() {
+				docs = append(docs, res.render(sp, env))
+			}
+
+			return s.query(r, docs, kind != kindContentType)
+		case http.MethodPost:
+			return s.create(r, sp, env, c, kind, s.newID(kind))
+		}
+
+		return unknownRoute()
+	}
+
+	res := c.get(rest[0])
+	if res == nil && !(len(rest) == 1 && r.Method == http.MethodPut) {
+		return 0, nil, notFound("The %v %v does not exist.", kind, rest[0])
+	}
+
+	if len(rest) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			return http.StatusOK, res.render(sp, env), nil
+		case http.MethodPut:
+			if res == nil {
+				return s.create(r, sp, env, c, kind, rest[0])
+			}
+
+			return s.update(r, sp, env, res)
+		case http.MethodDelete:
+			return s.delete(env, c, res)
+		}
+
+		return unknownRoute()
+	}
+
+	switch {
+	case len(rest) == 2 && rest[1] == "published":
+		switch r.Method {
+		case http.MethodPut:
+			return s.publish(r, sp, env, res)
+		case http.MethodDelete:
+			return s.unpublish(r, sp, env, res)
+		}
+	case len(rest) == 2 && rest[1] == "archived" && kind != kindContentType:
+		switch r.Method {
+		case http.MethodPut:
+			return s.archive(r, sp, env, res)
+		case http.MethodDelete:
+			return s.unarchive(r, sp, env, res)
+		}
+	case len(rest) == 4 && rest[1] == "files" && rest[3] == "process" && kind == kindAsset && r.Method == http.MethodPut:
+		return s.process(sp, res, rest[2])
+	}
+
+	return unknownRoute()
+}
+
+func (s *Server) create(r *http.Request, sp *space, env *environment, c *collection, kind string, id string) (int, interface{}, error) {
+	if version := r.Header.Get("X-Contentful-Version"); version != "" && version != "0" {
+		return 0, nil, notFound("The %v %v does not exist.", kind, id)
+	}
+
+	doc, err := readDoc(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res := s.newResource(kind, id, doc)
+
+	switch kind {
+	case kindContentType:
+		if err = requireName(doc); err != nil {
+			return 0, nil, err
+		}
+	case kindEntry:
+		res.contentType = r.Header.Get("X-Contentful-Content-Type")
+		if res.contentType == "" {
+			return 0, nil, badRequest("The X-Contentful-Content-Type header is required to create an entry.")
+		}
+
+		if env.contentTypes.get(res.contentType) == nil {
+			return 0, nil, &apiError{
+				status:  http.StatusUnprocessableEntity,
+				id:      "UnknownContentType",
+				message: fmt.Sprintf("The content type %v does not exist.", res.contentType),
+			}
+		}
+	}
+
+	c.add(res)
+
+	return http.StatusCreated, res.render(sp, env), nil
+}
+
+func (s *Server) update(r *http.Request, sp *space, env *environment, res *resource) (int, interface{}, error) {
+	if err := checkVersion(r, res, true); err != nil {
+		return 0, nil, err
+	}
+
+	if res.archived() {
+		return 0, nil, badRequest("The %v %v is archived and cannot be updated.", res.kind, res.id)
+	}
+
+	doc, err := readDoc(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if res.kind == kindContentType {
+		if err = requireName(doc); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	s.updateDoc(res, doc)
+
+	return http.StatusOK, res.render(sp, env), nil
+}
+
+func (s *Server) delete(env *environment, c *collection, res *resource) (int, interface{}, error) {
+	if res.published != nil {
+		return 0, nil, badRequest("The %v %v is published and cannot be deleted.", res.kind, res.id)
+	}
+
+	if res.kind == kindContentType {
+		for _, entry := range env.entries.all() {
+			if entry.contentType == res.id {
+				return 0, nil, badRequest("The content type %v still has entries and cannot be deleted.", res.id)
+			}
+		}
+	}
+
+	c.remove(res.id)
+
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) publish(r *http.Request, sp *space, env *environment, res *resource) (int, interface{}, error) {
+	if err := checkVersion(r, res, true); err != nil {
+		return 0, nil, err
+	}
+
+	if res.archived() {
+		return 0, nil, badRequest("The %v %v is archived and cannot be published.", res.kind, res.id)
+	}
+
+	switch res.kind {
+	case kindEntry:
+		if err := s.validateEntry(sp, env, res); err != nil {
+			return 0, nil, err
+		}
+	case kindAsset:
+		if err := validateAsset(res); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	now := s.now()
+	res.published = copyMap(res.doc)
+	res.publishedVersion = res.version
+	res.publishedCounter++
+	res.publishedAt = now
+	if res.firstPublishedAt.IsZero() {
+		res.firstPublishedAt = now
+	}
+
+	res.version++
+	res.updatedAt = now
+	env.publish(res, false, now)
+
+	return http.StatusOK, res.render(sp, env), nil
+}
+
+func (s *Server) unpublish(r *http.Request, sp *space, env *environment, res *resource) (int, interface{}, error) {
+	if err := checkVersion(r, res, false); err != nil {
+		return 0, nil, err
+	}
+
+	if res.published == nil {
+		return 0, nil, badRequest("The %v %v is not published.", res.kind, res.id)
+	}
+
+	if res.kind == kindContentType {
+		for _, entry := range env.entries.all() {
+			if entry.contentType == res.id && entry.published != nil {
+				return 0, nil, badRequest("The content type %v still has published entries.", res.id)
+			}
+		}
+	}
+
+	now := s.now()
+	res.published = nil
+	res.publishedAt = time.Time{}
+	res.version++
+	res.updatedAt = now
+	env.publish(res, true, now)
+
+	return http.StatusOK, res.render(sp, env), nil
+}
+
+func (s *Server) archive(r *http.Request, sp *space, env *environment, res *resource) (int, interface{}, error) {
+	if err := checkVersion(r, res, false); err != nil {
+		return 0, nil, err
+	}
+
+	if res.published != nil {
+		return 0, nil, badRequest("The %v %v is published and cannot be archived.", res.kind, res.id)
+	}
+
+	if res.archived() {
+		return 0, nil, badRequest("The %v %v is already archived.", res.kind, res.id)
+	}
+
+	now := s.now()
+	res.archivedVersion = res.version
+	res.archivedAt = now
+	res.version++
+	res.updatedAt = now
+
+	return http.StatusOK, res.render(sp, env), nil
+}
+
+func (s *Server) unarchive(r *http.Request, sp *space, env *environment, res *resource) (int, interface{}, error) {
+	if err := checkVersion(r, res, false); err != nil {
+		return 0, nil, err
+	}
+
+	if !res.archived() {
+		return 0, nil, badRequest("The %v %v is not archived.", res.kind, res.id)
+	}
+
+	res.archivedVersion = 0
+	res.archivedAt = time.Time{}
+	res.version++
+	res.updatedAt = s.now()
+
+	return http.StatusOK, res.render(sp, env), nil
+}
+
+// process processes the file of an asset immediately. Files uploaded through
+// the Upload API report their size.
+func (s *Server) process(sp *space, res *resource, locale string) (int, interface{}, error) {
+	files, _ := fieldsOf(res.doc)["file"].(map[string]interface{})
+	file, ok := files[locale].(map[string]interface{})
+	if !ok {
+		return 0, nil, notFound("The asset %v has no file for locale %v.", res.id, locale)
+	}
+
+	if url, _ := file["url"].(string); url != "" {
+		return http.StatusNoContent, nil, nil
+	}
+
+	details, ok := file["details"].(map[string]interface{})
+	if !ok {
+		details = map[string]interface{}{"size": 0}
+	}
+
+	if uploadFrom, ok := file["uploadFrom"].(map[string]interface{}); ok {
+		sys, _ := uploadFrom["sys"].(map[string]interface{})
+		id, _ := sys["id"].(string)
+
+		u := sp.uploads[id]
+		if u == nil {
+			return 0, nil, validationFailed(nil, "The upload %v does not exist.", id)
+		}
+
+		details["size"] = len(u.data)
+	}
+
+	file["url"] = fmt.Sprintf("//assets.ctfassets.net/%v/%v/%v", sp.id, res.id, file["fileName"])
+	file["details"] = details
+	delete(file, "upload")
+	delete(file, "uploadFrom")
+
+	res.version++
+	res.updatedAt = s.now()
+
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) uploads(r *http.Request, sp *space, rest []string) (int, interface{}, error) {
+	if rest[0] != "uploads" || len(rest) > 2 {
+		return unknownRoute()
+	}
+
+	if len(rest) == 1 {
+		if r.Method != http.MethodPost {
+			return unknownRoute()
+		}
+
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		u := &upload{resource: s.newResource(kindUpload, s.newID(kindUpload), nil), data: data}
+		sp.uploads[u.id] = u
+
+		return http.StatusCreated, u.render(sp), nil
+	}
+
+	u := sp.uploads[rest[1]]
+	if u == nil {
+		return 0, nil, notFound("The upload %v does not exist.", rest[1])
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, u.render(sp), nil
+	case http.MethodDelete:
+		delete(sp.uploads, u.id)
+		return http.StatusNoContent, nil, nil
+	}
+
+	return unknownRoute()
+}
+
+// validateEntry validates the entry against its published content type
+func (s *Server) validateEntry(sp *space, env *environment, res *resource) error {
+	ct := env.contentTypes.get(res.contentType)
+	if ct == nil || ct.published == nil {
+		return validationFailed(nil, "The content type %v is not published.", res.contentType)
+	}
+
+	contentType := new(models.ContentType)
+	if err := convert(ct.published, contentType); err != nil {
+		return err
+	}
+
+	locales := []*models.Locale{}
+	if err := convert(renderAll(env.locales, sp, env), &locales); err != nil {
+		return err
+	}
+
+	includes := &models.Includes{}
+	if err := convert(renderAll(env.entries, sp, env), &includes.Entries); err != nil {
+		return err
+	}
+
+	if err := convert(renderAll(env.assets, sp, env), &includes.Assets); err != nil {
+		return err
+	}
+
+	entry := new(models.Entry)
+	if err := convert(res.doc, entry); err != nil {
+		return err
+	}
+
+	validator := models.NewEntryValidator(contentType, locales)
+	validator.Includes = includes
+
+	err := validator.Validate(entry.Fields)
+	fieldErrors, ok := err.(models.FieldErrors)
+	if !ok {
+		return err
+	}
+
+	details := []interface{}{}
+	for _, fieldError := range fieldErrors {
+		path := []interface{}{"fields", fieldError.Field}
+		if fieldError.Locale != "" {
+			path = append(path, fieldError.Locale)
+		}
+
+		details = append(details, validationDetail(fieldError.Validation, path, fieldError.Message))
+	}
+
+	return validationFailed(details, "Validation error")
+}
+
+// validateAsset checks that every file of the asset has been processed
+func validateAsset(res *resource) error {
+	files, _ := fieldsOf(res.doc)["file"].(map[string]interface{})
+
+	details := []interface{}{}
+	for _, locale := range sortedKeys(files) {
+		file, _ := files[locale].(map[string]interface{})
+		if url, _ := file["url"].(string); url == "" {
+			details = append(details, validationDetail("required", []interface{}{"fields", "file", locale, "url"}, "The file has not been processed."))
+		}
+	}
+
+	if len(details) > 0 {
+		return validationFailed(details, "Validation error")
+	}
+
+	return nil
+}
+
+// checkVersion compares the X-Contentful-Version header to the version of the
+// resource. Without the header the check fails only if required is set.
+func checkVersion(r *http.Request, res *resource, required bool) error {
+	header := r.Header.Get("X-Contentful-Version")
+	if header == "" {
+		if required {
+			return versionMismatch("The X-Contentful-Version header is required.")
+		}
+
+		return nil
+	}
+
+	version, err := strconv.Atoi(header)
+	if err != nil || version != res.version {
+		return versionMismatch("Version %v does not match the current version %v of %v %v.", header, res.version, res.kind, res.id)
+	}
+
+	return nil
+}
+
+func (s *Server) updateDoc(res *resource, doc map[string]interface{}) {
+	res.doc = doc
+	res.version++
+	res.updatedAt = s.now()
+}
+
+func requireName(doc map[string]interface{}) error {
+	if name, _ := doc["name"].(string); name == "" {
+		return validationFailed([]interface{}{validationDetail("required", []interface{}{"name"}, "The name is required.")}, "Validation error")
+	}
+
+	return nil
+}
+
+func validationDetail(name string, path []interface{}, details string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":    name,
+		"path":    path,
+		"details": details,
+	}
+}
+
+func renderAll(c *collection, sp *space, env *environment) []map[string]interface{} {
+	docs := []map[string]interface{}{}
+	for _, res := range c.all() {
+		docs = append(docs, res.render(sp, env))
+	}
+
+	return docs
+}
+
+// convert converts decoded JSON into the models using their json tags
+func convert(from interface{}, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, to)
+}
@@ -0,0 +1,530 @@
+package contentfultest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Pagination defaults and limits of the APIs
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// parameters are the query parameters that are not filters
+var parameters = map[string]bool{
+	"access_token": true,
+	"locale":       true,
+	"skip":         true,
+	"limit":        true,
+	"order":        true,
+	"include":      true,
+}
+
+// operators are the supported search operators, the empty operator tests for
+// equality
+var operators = map[string]bool{
+	"": true, "ne": true, "in": true, "nin": true, "all": true, "exists": true,
+	"lt": true, "lte": true, "gt": true, "gte": true, "match": true,
+}
+
+// query filters, orders and paginates the documents of a collection endpoint.
+// Fields of localized documents map locale codes to values, filters match the
+// value of any locale unless the path names a locale.
+func (s *Server) query(r *http.Request, docs []map[string]interface{}, localized bool) (int, interface{}, error) {
+	q := r.URL.Query()
+
+	filters, err := parseFilters(q)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	matched := []map[string]interface{}{}
+	for _, doc := range docs {
+		if matchesAll(doc, filters, localized) {
+			matched = append(matched, doc)
+		}
+	}
+
+	if order := q.Get("order"); order != "" {
+		keys := strings.Split(order, ",")
+		for _, key := range keys {
+			path := strings.TrimPrefix(key, "-")
+			if !strings.HasPrefix(path, "sys.") && !strings.HasPrefix(path, "fields.") {
+				return 0, nil, invalidQuery("Cannot order by %v.", key)
+			}
+		}
+
+		sortDocs(matched, keys, localized)
+	}
+
+	skip, err := intParameter(q, "skip", 0)
+	if err != nil || skip < 0 {
+		return 0, nil, invalidQuery("The skip parameter must be a positive number.")
+	}
+
+	limit, err := intParameter(q, "limit", defaultLimit)
+	if err != nil || limit < 0 || limit > maxLimit {
+		return 0, nil, invalidQuery("The limit parameter must be between 0 and %v.", maxLimit)
+	}
+
+	start, end := skip, skip+limit
+	if start > len(matched) {
+		start = len(matched)
+	}
+
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return http.StatusOK, map[string]interface{}{
+		"sys":   map[string]interface{}{"type": "Array"},
+		"total": len(matched),
+		"skip":  skip,
+		"limit": limit,
+		"items": matched[start:end],
+	}, nil
+}
+
+func intParameter(q url.Values, name string, defaultValue int) (int, error) {
+	value := q.Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+// filter is a single search parameter such as fields.title[match]=hello
+type filter struct {
+	path     string
+	operator string
+	value    string
+}
+
+func parseFilters(q url.Values) ([]filter, error) {
+	filters := []filter{}
+
+	for _, key := range sortedKeys(q) {
+		if parameters[key] {
+			continue
+		}
+
+		value := q.Get(key)
+
+		switch key {
+		case "content_type":
+			filters = append(filters, filter{path: "sys.contentType.sys.id", value: value})
+			continue
+		case "query":
+			filters = append(filters, filter{operator: "query", value: strings.ToLower(value)})
+			continue
+		case "links_to_entry":
+			filters = append(filters, filter{path: kindEntry, operator: "links", value: value})
+			continue
+		case "links_to_asset":
+			filters = append(filters, filter{path: kindAsset, operator: "links", value: value})
+			continue
+		}
+
+		path, operator := key, ""
+		if i := strings.Index(key, "["); i >= 0 && strings.HasSuffix(key, "]") {
+			path, operator = key[:i], key[i+1:len(key)-1]
+		}
+
+		if !strings.HasPrefix(path, "sys.") && !strings.HasPrefix(path, "fields.") {
+			return nil, invalidQuery("The query parameter %v is not supported.", key)
+		}
+
+		if !operators[operator] {
+			return nil, invalidQuery("The %v operator is not supported.", operator)
+		}
+
+		filters = append(filters, filter{path: path, operator: operator, value: value})
+	}
+
+	return filters, nil
+}
+
+func matchesAll(doc map[string]interface{}, filters []filter, localized bool) bool {
+	for _, f := range filters {
+		if !f.matches(doc, localized) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f filter) matches(doc map[string]interface{}, localized bool) bool {
+	switch f.operator {
+	case "query":
+		return containsText(doc["fields"], f.value)
+	case "links":
+		for _, l := range links(doc["fields"]) {
+			if l.linkType == f.path && l.id == f.value {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	found := values(doc, f.path, localized)
+
+	switch f.operator {
+	case "":
+		return anyValue(found, func(v interface{}) bool { return format(v) == f.value })
+	case "ne":
+		return !anyValue(found, func(v interface{}) bool { return format(v) == f.value })
+	case "in":
+		set := valueSet(f.value)
+		return anyValue(found, func(v interface{}) bool { return set[format(v)] })
+	case "nin":
+		set := valueSet(f.value)
+		return !anyValue(found, func(v interface{}) bool { return set[format(v)] })
+	case "all":
+		for expected := range valueSet(f.value) {
+			if !anyValue(found, func(v interface{}) bool { return format(v) == expected }) {
+				return false
+			}
+		}
+
+		return true
+	case "exists":
+		return (len(found) > 0) == (f.value == "true")
+	case "match":
+		text := strings.ToLower(f.value)
+		return anyValue(found, func(v interface{}) bool {
+			s, ok := v.(string)
+			return ok && strings.Contains(strings.ToLower(s), text)
+		})
+	}
+
+	return anyValue(found, func(v interface{}) bool {
+		c, ok := compareTo(v, f.value)
+		if !ok {
+			return false
+		}
+
+		switch f.operator {
+		case "lt":
+			return c < 0
+		case "lte":
+			return c <= 0
+		case "gt":
+			return c > 0
+		}
+
+		return c >= 0
+	})
+}
+
+// values returns the values at the dotted path of the document. Arrays are
+// flattened, so filters match any of their items.
+func values(doc map[string]interface{}, path string, localized bool) []interface{} {
+	parts := strings.Split(path, ".")
+
+	localeAt := -1
+	if localized && parts[0] == "fields" {
+		localeAt = 2
+	}
+
+	return lookup(doc, parts, localeAt)
+}
+
+// lookup resolves the path in value. localeAt counts the path elements until
+// the value is a map of locale codes, which is looked into for the locale
+// named by the path or, failing that, for every locale.
+func lookup(value interface{}, parts []string, localeAt int) []interface{} {
+	if items, ok := value.([]interface{}); ok {
+		found := []interface{}{}
+		for _, item := range items {
+			found = append(found, lookup(item, parts, localeAt)...)
+		}
+
+		return found
+	}
+
+	m, isMap := value.(map[string]interface{})
+	if localeAt == 0 && isMap {
+		if len(parts) > 0 {
+			if localized, ok := m[parts[0]]; ok {
+				return lookup(localized, parts[1:], -1)
+			}
+		}
+
+		found := []interface{}{}
+		for _, code := range sortedKeys(m) {
+			found = append(found, lookup(m[code], parts, -1)...)
+		}
+
+		return found
+	}
+
+	if len(parts) == 0 {
+		if value == nil {
+			return nil
+		}
+
+		return []interface{}{value}
+	}
+
+	if !isMap {
+		return nil
+	}
+
+	child, ok := m[parts[0]]
+	if !ok {
+		return nil
+	}
+
+	return lookup(child, parts[1:], localeAt-1)
+}
+
+func anyValue(values []interface{}, f func(v interface{}) bool) bool {
+	for _, v := range values {
+		if f(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func valueSet(value string) map[string]bool {
+	set := map[string]bool{}
+	for _, item := range strings.Split(value, ",") {
+		set[item] = true
+	}
+
+	return set
+}
+
+// format returns the value as it is written in query parameters
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+var dateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04Z07:00", "2006-01-02T15:04", "2006-01-02"}
+
+func parseDate(s string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// compareTo compares a value with a query parameter. Numbers are compared
+// numerically, dates chronologically and other strings lexically.
+func compareTo(value interface{}, parameter string) (int, bool) {
+	if n, ok := number(value); ok {
+		p, err := strconv.ParseFloat(parameter, 64)
+		if err != nil {
+			return 0, false
+		}
+
+		return compareFloats(n, p), true
+	}
+
+	switch v := value.(type) {
+	case string:
+		if t, ok := parseDate(v); ok {
+			if p, ok := parseDate(parameter); ok {
+				return compareTimes(t, p), true
+			}
+		}
+
+		return strings.Compare(v, parameter), true
+	}
+
+	return 0, false
+}
+
+// compareValues orders two values, missing values are ordered last
+func compareValues(a interface{}, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			return compareFloats(x, y)
+		}
+	}
+
+	if x, ok := a.(string); ok {
+		if y, ok := b.(string); ok {
+			if t, ok := parseDate(x); ok {
+				if u, ok := parseDate(y); ok {
+					return compareTimes(t, u)
+				}
+			}
+		}
+	}
+
+	return strings.Compare(format(a), format(b))
+}
+
+// number returns decoded JSON numbers and the integers of system properties as
+// floats
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	}
+
+	return 0, false
+}
+
+func compareFloats(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func compareTimes(a time.Time, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+
+	return 0
+}
+
+// sortDocs orders the documents by the keys of the order parameter, keys
+// prefixed with a minus are sorted in descending order
+func sortDocs(docs []map[string]interface{}, keys []string, localized bool) {
+	first := func(doc map[string]interface{}, path string) interface{} {
+		if found := values(doc, path, localized); len(found) > 0 {
+			return found[0]
+		}
+
+		return nil
+	}
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		for _, key := range keys {
+			path := strings.TrimPrefix(key, "-")
+			c := compareValues(first(docs[i], path), first(docs[j], path))
+			if c == 0 {
+				continue
+			}
+
+			if strings.HasPrefix(key, "-") {
+				return c > 0
+			}
+
+			return c < 0
+		}
+
+		return false
+	})
+}
+
+// containsText reports whether any text in value contains the lower case text
+func containsText(value interface{}, text string) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.Contains(strings.ToLower(v), text)
+	case map[string]interface{}:
+		for _, item := range v {
+			if containsText(item, text) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if containsText(item, text) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// linkRef is a link to an entry or asset
+type linkRef struct {
+	linkType string
+	id       string
+}
+
+// links returns the links to entries and assets in value, in a stable order
+func links(value interface{}) []linkRef {
+	found := []linkRef{}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if sys, ok := v["sys"].(map[string]interface{}); ok && sys["type"] == "Link" {
+			linkType, _ := sys["linkType"].(string)
+			id, _ := sys["id"].(string)
+			if linkType == kindEntry || linkType == kindAsset {
+				found = append(found, linkRef{linkType: linkType, id: id})
+			}
+
+			return found
+		}
+
+		for _, key := range sortedKeys(v) {
+			found = append(found, links(v[key])...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			found = append(found, links(item)...)
+		}
+	}
+
+	return found
+}
+
+// sortedKeys returns the keys of a map with string keys in order
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+
+	switch v := m.(type) {
+	case map[string]interface{}:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case url.Values:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,292 @@
+// Package contentfultest provides an in-memory fake of the Contentful
+// Management, Delivery, Preview and Upload APIs for tests.
+//
+// The server keeps spaces, environments, locales, content types, entries,
+// assets and uploads in memory and behaves like Contentful where it matters to
+// the clients: updates are checked against the X-Contentful-Version header,
+// entries and assets can be published and archived, published entries are
+// validated against their content type, the Delivery API only serves published
+// content and collections support the common query filters.
+//
+//	server := contentfultest.NewServer()
+//	defer server.Close()
+//
+//	server.CreateSpace("example", "Example")
+//
+//	cma := management.New("token",
+//		management.WithBaseURL(server.ManagementURL()),
+//		management.WithUploadBaseURL(server.UploadURL()),
+//		management.WithRateLimit(0, 0))
+//	cda := delivery.New("token",
+//		delivery.WithBaseURL(server.DeliveryURL()),
+//		delivery.WithRateLimit(0, 0))
+//
+// ManagementClient returns a management client configured like this, without
+// retries. Writes records the requests of a client that change a space.
+//
+// Access tokens are not checked. API keys, webhooks, environment aliases, the
+// select parameter and location queries are not supported.
+package contentfultest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIs served by the server, every API is served under its own path prefix
+const (
+	managementAPI = "management"
+	deliveryAPI   = "delivery"
+	previewAPI    = "preview"
+	uploadAPI     = "upload"
+)
+
+// DefaultLocale is the code of the default locale of spaces created on the
+// server
+const DefaultLocale = "en-US"
+
+// masterEnvironment is used for paths that don't specify an environment
+const masterEnvironment = "master"
+
+// Server is a fake Contentful server. Use the ManagementURL, DeliveryURL,
+// PreviewURL and UploadURL as base URLs of the clients.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	spaces   map[string]*space
+	spaceIDs []string
+	ids      int
+	requests int
+}
+
+// NewServer starts a fake Contentful server without any spaces. The caller
+// should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		spaces: map[string]*space{},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// ManagementURL returns the base URL of the Content Management API
+func (s *Server) ManagementURL() string {
+	return s.URL + "/" + managementAPI
+}
+
+// DeliveryURL returns the base URL of the Content Delivery API
+func (s *Server) DeliveryURL() string {
+	return s.URL + "/" + deliveryAPI
+}
+
+// PreviewURL returns the base URL of the Content Preview API, which serves the
+// latest drafts of entries and assets
+func (s *Server) PreviewURL() string {
+	return s.URL + "/" + previewAPI
+}
+
+// UploadURL returns the base URL of the Upload API
+func (s *Server) UploadURL() string {
+	return s.URL + "/" + uploadAPI
+}
+
+// CreateSpace adds a space with a master environment and the en-US default
+// locale, replacing any existing space with the same identifier.
+func (s *Server) CreateSpace(spaceID string, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addSpace(spaceID, map[string]interface{}{"name": name})
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	requestID := fmt.Sprintf("fake-%v", s.requests)
+	w.Header().Set("X-Contentful-Request-Id", requestID)
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	status, body, err := s.route(r, segments[0], segments[1:])
+	if err != nil {
+		apiErr, ok := err.(*apiError)
+		if !ok {
+			apiErr = &apiError{status: http.StatusInternalServerError, id: "ServerError", message: err.Error()}
+		}
+
+		status, body = apiErr.status, apiErr.body(requestID)
+	}
+
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (s *Server) route(r *http.Request, api string, segments []string) (int, interface{}, error) {
+	switch api {
+	case managementAPI, deliveryAPI, previewAPI, uploadAPI:
+	default:
+		return unknownRoute()
+	}
+
+	if len(segments) == 0 || segments[0] != "spaces" {
+		return unknownRoute()
+	}
+
+	if len(segments) == 1 {
+		if api != managementAPI {
+			return unknownRoute()
+		}
+
+		return s.spaceCollection(r)
+	}
+
+	sp, ok := s.spaces[segments[1]]
+	if !ok {
+		return 0, nil, notFound("The space %v does not exist.", segments[1])
+	}
+
+	rest := segments[2:]
+	if len(rest) == 0 {
+		return s.space(r, api, sp)
+	}
+
+	environmentID := masterEnvironment
+	if rest[0] == "environments" {
+		if len(rest) <= 2 {
+			if api != managementAPI {
+				return unknownRoute()
+			}
+
+			return s.environments(r, sp, rest[1:])
+		}
+
+		environmentID, rest = rest[1], rest[2:]
+	}
+
+	env, ok := sp.environments[environmentID]
+	if !ok {
+		return 0, nil, notFound("The environment %v does not exist.", environmentID)
+	}
+
+	switch api {
+	case managementAPI:
+		return s.management(r, sp, env, rest)
+	case uploadAPI:
+		return s.uploads(r, sp, rest)
+	}
+
+	return s.delivery(r, api == previewAPI, sp, env, rest)
+}
+
+// readDoc decodes the JSON body of the request, dropping the system properties
+func readDoc(r *http.Request) (map[string]interface{}, error) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := map[string]interface{}{}
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if err = json.Unmarshal(data, &doc); err != nil {
+			return nil, badRequest("The body you sent is not valid JSON.")
+		}
+	}
+
+	delete(doc, "sys")
+
+	return doc, nil
+}
+
+func (s *Server) now() time.Time {
+	return time.Now().UTC()
+}
+
+// newID generates an identifier such as "entry12" for a resource of the kind
+func (s *Server) newID(kind string) string {
+	s.ids++
+	return fmt.Sprintf("%v%v", strings.ToLower(kind[:1])+kind[1:], s.ids)
+}
+
+func (s *Server) newResource(kind string, id string, doc map[string]interface{}) *resource {
+	now := s.now()
+
+	return &resource{
+		id:        id,
+		kind:      kind,
+		version:   1,
+		createdAt: now,
+		updatedAt: now,
+		doc:       doc,
+	}
+}
+
+// apiError is rendered like the errors of the Contentful APIs
+type apiError struct {
+	status  int
+	id      string
+	message string
+	details []interface{}
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%v: %v", e.id, e.message)
+}
+
+func (e *apiError) body(requestID string) map[string]interface{} {
+	body := map[string]interface{}{
+		"sys":       map[string]interface{}{"type": "Error", "id": e.id},
+		"message":   e.message,
+		"requestId": requestID,
+	}
+
+	if len(e.details) > 0 {
+		body["details"] = map[string]interface{}{"errors": e.details}
+	}
+
+	return body
+}
+
+func unknownRoute() (int, interface{}, error) {
+	return 0, nil, notFound("The resource could not be found.")
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusNotFound, id: "NotFound", message: fmt.Sprintf(format, args...)}
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusBadRequest, id: "BadRequest", message: fmt.Sprintf(format, args...)}
+}
+
+func invalidQuery(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusBadRequest, id: "InvalidQuery", message: fmt.Sprintf(format, args...)}
+}
+
+func versionMismatch(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusConflict, id: "VersionMismatch", message: fmt.Sprintf(format, args...)}
+}
+
+func validationFailed(details []interface{}, format string, args ...interface{}) error {
+	return &apiError{
+		status:  http.StatusUnprocessableEntity,
+		id:      "ValidationFailed",
+		message: fmt.Sprintf(format, args...),
+		details: details,
+	}
+}
@@ -0,0 +1,329 @@
+package contentfultest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+
+	"github.com/illyabusigin/contentful/delivery"
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+const spaceID = "space"
+
+func newClients(server *Server) (*management.Client, *delivery.Client, *delivery.Client) {
+	cma := server.ManagementClient()
+
+	cda := delivery.New("token",
+		delivery.WithBaseURL(server.DeliveryURL()),
+		delivery.WithRateLimit(0, 0),
+		delivery.WithRetryPolicy(nil))
+
+	cpa := delivery.New("token",
+		delivery.WithBaseURL(server.PreviewURL()),
+		delivery.WithRateLimit(0, 0),
+		delivery.WithRetryPolicy(nil))
+
+	return cma, cda, cpa
+}
+
+func createPostType(t *testing.T, cma *management.Client) *ContentType {
+	contentType := &ContentType{
+		System: System{
+			ID:    "post",
+			Space: &Link{LinkData: &LinkData{Type: LinkType, LinkType: "Space", ID: spaceID}},
+		},
+		Name:         "Post",
+		DisplayField: "title",
+		Fields: []Field{
+			{ID: "title", Name: "Title", Type: ShortText, Required: true, Localized: true},
+			{ID: "rating", Name: "Rating", Type: Integer},
+			{ID: "related", Name: "Related", Type: LinkType, LinkType: "Entry"},
+		},
+	}
+
+	created, err := cma.CreateContentType(contentType)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, created.Version)
+
+	published, err := cma.ActivateContentType(created)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, published.Version)
+
+	return published
+}
+
+func TestEntryLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.CreateSpace(spaceID, "Test")
+	cma, cda, cpa := newClients(server)
+	contentType := createPostType(t, cma)
+
+	entry, err := cma.CreateEntry(&NewEntry{Fields: EntryFields{
+		"rating": map[string]interface{}{"en-US": 3},
+	}}, contentType)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, entry.Version)
+	assert.Equal(t, "post", entry.ContentType.ID)
+
+	// The required title is missing
+	_, err = cma.PublishEntry(entry)
+	assert.True(t, errors.Is(err, ErrValidationFailed))
+
+	var contentfulError *management.ContentfulError
+	assert.True(t, errors.As(err, &contentfulError))
+	problems := contentfulError.ValidationErrors()
+	assert.Len(t, problems, 1)
+	assert.Equal(t, "title", problems[0].Field())
+	assert.Equal(t, "required", problems[0].Name)
+
+	entry.Fields["title"] = map[string]interface{}{"en-US": "Hello"}
+	updated, err := cma.UpdateEntry(entry)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, updated.Version)
+
+	// The entry is still at version 1
+	_, err = cma.UpdateEntry(entry)
+	assert.True(t, errors.Is(err, ErrVersionMismatch))
+
+	// Drafts are only visible through the Preview API
+	_, err = cda.FetchEntry(spaceID, entry.ID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	preview, err := cpa.FetchEntry(spaceID, entry.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"en-US": "Hello"}, preview.Fields["title"])
+
+	published, err := cma.PublishEntry(updated)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, published.Version)
+	assert.Equal(t, 2, published.PublishedVersion)
+	assert.NotNil(t, published.PublishedAt)
+
+	delivered, err := cda.FetchEntry(spaceID, entry.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"en-US": "Hello"}, delivered.Fields["title"])
+
+	_, err = cma.ArchiveEntry(published)
+	assert.True(t, errors.Is(err, ErrBadRequest))
+
+	unpublished, err := cma.UnpublishEntry(published)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, unpublished.Version)
+
+	_, err = cda.FetchEntry(spaceID, entry.ID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	archived, err := cma.ArchiveEntry(unpublished)
+	assert.Nil(t, err)
+	assert.NotNil(t, archived.ArchivedAt)
+
+	_, err = cma.UpdateEntry(archived)
+	assert.True(t, errors.Is(err, ErrBadRequest))
+
+	_, err = cma.UnarchiveEntry(archived)
+	assert.Nil(t, err)
+
+	assert.Nil(t, cma.DeleteEntry(entry.ID, spaceID))
+
+	_, err = cma.FetchEntry(spaceID, entry.ID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestQueryEntries(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.CreateSpace(spaceID, "Test")
+	cma, cda, _ := newClients(server)
+	contentType := createPostType(t, cma)
+
+	titles := []string{"Banana", "Apple", "Cherry"}
+	ids := []string{}
+	for i, title := range titles {
+		fields := EntryFields{
+			"title":  map[string]interface{}{"en-US": title},
+			"rating": map[string]interface{}{"en-US": i + 1},
+		}
+
+		if i > 0 {
+			fields["related"] = map[string]interface{}{"en-US": (&Entry{System: System{ID: ids[0]}}).Link()}
+		}
+
+		entry, err := cma.CreateEntry(&NewEntry{Fields: fields}, contentType)
+		assert.Nil(t, err)
+
+		_, err = cma.PublishEntry(entry)
+		assert.Nil(t, err)
+
+		ids = append(ids, entry.ID)
+	}
+
+	result := cda.SearchEntries(spaceID, NewQuery().ContentType("post").Order("fields.title"), 10, 0)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 3, result.Pagination.Total)
+	assert.Equal(t, "Apple", result.Entries[0].Fields["title"].(map[string]interface{})["en-US"])
+	assert.Equal(t, "Cherry", result.Entries[2].Fields["title"].(map[string]interface{})["en-US"])
+
+	// Banana is linked from the other entries but part of the items
+	result = cda.SearchEntries(spaceID, NewQuery().GreaterThan("fields.rating", 1), 10, 0)
+	assert.Empty(t, result.Errors)
+	assert.Len(t, result.Entries, 2)
+	assert.Len(t, result.Includes.Entries, 1)
+	assert.Equal(t, ids[0], result.Includes.Entries[0].ID)
+
+	result = cda.SearchEntries(spaceID, NewQuery().Match("fields.title", "err"), 10, 0)
+	assert.Empty(t, result.Errors)
+	assert.Len(t, result.Entries, 1)
+	assert.Equal(t, ids[2], result.Entries[0].ID)
+
+	result = cda.SearchEntries(spaceID, NewQuery().In("sys.id", ids[0], ids[1]).LinksToEntry(ids[0]), 10, 0)
+	assert.Empty(t, result.Errors)
+	assert.Len(t, result.Entries, 1)
+	assert.Equal(t, ids[1], result.Entries[0].ID)
+
+	result = cma.SearchEntries(spaceID, NewQuery().OrderReversed("fields.rating"), 2, 1)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 3, result.Pagination.Total)
+	assert.Len(t, result.Entries, 2)
+	assert.Equal(t, ids[1], result.Entries[0].ID)
+
+	result = cda.SearchEntries(spaceID, NewQuery().Param("select", "fields.title"), 10, 0)
+	assert.Len(t, result.Errors, 1)
+	assert.True(t, errors.Is(result.Errors[0], ErrBadRequest))
+}
+
+func TestAssetUploadAndSync(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.CreateSpace(spaceID, "Test")
+	cma, cda, _ := newClients(server)
+
+	initial, err := cda.InitialSync(spaceID, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, initial.Assets)
+
+	data := []byte("hello world")
+	upload, err := cma.Upload(spaceID, bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+
+	created, err := cma.CreateAsset(&File{
+		SpaceID: spaceID,
+		Fields: FileFields{
+			Title: map[string]string{"en-US": "Greeting"},
+			File:  map[string]FileData{"en-US": upload.FileData("hello.txt")},
+		},
+	})
+	assert.Nil(t, err)
+
+	_, err = cma.PublishAsset(created)
+	assert.True(t, errors.Is(err, ErrValidationFailed))
+
+	processed, err := cma.ProcessAssetAndWait(created, &management.ProcessAssetOptions{Publish: true})
+	assert.Nil(t, err)
+	assert.NotNil(t, processed.PublishedAt)
+
+	asset, err := cda.FetchAsset(spaceID, created.ID)
+	assert.Nil(t, err)
+	assert.Contains(t, asset.Fields.File["en-US"].URL, "hello.txt")
+	assert.Equal(t, len(data), asset.Fields.File["en-US"].Detail.Size)
+
+	delta, err := cda.Sync(spaceID, initial.NextSyncToken)
+	assert.Nil(t, err)
+	assert.Len(t, delta.Assets, 1)
+	assert.Equal(t, created.ID, delta.Assets[0].ID)
+
+	_, err = cma.UnpublishAsset(processed)
+	assert.Nil(t, err)
+
+	delta, err = cda.Sync(spaceID, delta.NextSyncToken)
+	assert.Nil(t, err)
+	assert.Empty(t, delta.Assets)
+	assert.Len(t, delta.DeletedAssets, 1)
+	assert.Equal(t, created.ID, delta.DeletedAssets[0].ID)
+}
+
+func TestEnvironmentsAndLocales(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.CreateSpace(spaceID, "Test")
+	cma, cda, _ := newClients(server)
+	contentType := createPostType(t, cma)
+
+	german, err := cma.CreateLocale(spaceID, &Locale{Name: "German", Code: "de-DE", Fallback: "en-US", Optional: true})
+	assert.Nil(t, err)
+
+	_, err = cma.CreateLocale(spaceID, &Locale{Name: "German", Code: "de-DE"})
+	assert.True(t, errors.Is(err, ErrValidationFailed))
+
+	entry, err := cma.CreateEntry(&NewEntry{Fields: EntryFields{
+		"title": map[string]interface{}{"en-US": "Hello", "de-DE": "Hallo"},
+	}}, contentType)
+	assert.Nil(t, err)
+
+	_, err = cma.PublishEntry(entry)
+	assert.Nil(t, err)
+
+	staging, err := cma.CreateEnvironment(spaceID, &Environment{System: System{ID: "staging"}, Name: "Staging"}, "")
+	assert.Nil(t, err)
+	assert.True(t, staging.Ready())
+
+	// The environment is a copy of master
+	copied, err := cma.InEnvironment("staging").FetchEntry(spaceID, entry.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entry.Fields, copied.Fields)
+
+	assert.Nil(t, cma.DeleteLocale(spaceID, german.ID))
+
+	_, err = cma.InEnvironment("staging").FetchLocale(spaceID, german.ID)
+	assert.Nil(t, err)
+
+	locales, _, err := cda.InEnvironment("staging").FetchLocales(spaceID)
+	assert.Nil(t, err)
+	assert.Len(t, locales, 2)
+
+	_, err = cma.InEnvironment("missing").FetchEntry(spaceID, entry.ID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestWrites(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.CreateSpace(spaceID, "Test")
+	writes := &Writes{}
+	cma := server.ManagementClient(management.WithMiddleware(writes.Middleware))
+	contentType := createPostType(t, cma)
+
+	_, err := cma.FetchContentType(spaceID, contentType.ID)
+	assert.Nil(t, err)
+
+	_, err = cma.CreateEnvironment(spaceID, &Environment{System: System{ID: "staging"}, Name: "Staging"}, "")
+	assert.Nil(t, err)
+
+	entry, err := cma.InEnvironment("staging").CreateEntry(&NewEntry{ID: "hello", Fields: EntryFields{
+		"title": map[string]interface{}{"en-US": "Hello"},
+	}}, contentType)
+	assert.Nil(t, err)
+
+	// GET requests aren't recorded, paths are relative to the environment
+	assert.Equal(t, []string{
+		"PUT content_types/post",
+		"PUT content_types/post/published",
+		"PUT environments/staging",
+		"PUT entries/hello",
+	}, writes.Strings())
+
+	assert.Equal(t, "hello", entry.ID)
+	assert.Contains(t, string(writes.All()[3].Body), `"Hello"`)
+
+	writes.Reset()
+	assert.Empty(t, writes.All())
+}
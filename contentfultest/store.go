@@ -0,0 +1,420 @@
+package contentfultest
+
+import (
+	"time"
+)
+
+// Resource kinds, they match the sys.type of the resources
+const (
+	kindSpace       = "Space"
+	kindEnvironment = "Environment"
+	kindLocale      = "Locale"
+	kindContentType = "ContentType"
+	kindEntry       = "Entry"
+	kindAsset       = "Asset"
+	kindUpload      = "Upload"
+)
+
+// resource is a versioned document. The draft is kept in doc, the published
+// snapshot in published.
+type resource struct {
+	id          string
+	kind        string
+	contentType string
+	version     int
+	createdAt   time.Time
+	updatedAt   time.Time
+	doc         map[string]interface{}
+
+	published        map[string]interface{}
+	publishedVersion int
+	publishedCounter int
+	publishedAt      time.Time
+	firstPublishedAt time.Time
+	archivedVersion  int
+	archivedAt       time.Time
+
+	// seq is the sequence number of the environment when the resource was
+	// last published, sync tokens refer to it
+	seq int
+}
+
+func (r *resource) archived() bool {
+	return !r.archivedAt.IsZero()
+}
+
+func (r *resource) clone() *resource {
+	c := *r
+	c.doc = copyMap(r.doc)
+	c.published = copyMap(r.published)
+
+	return &c
+}
+
+// sys returns the system properties of the resource as returned by the
+// Management API
+func (r *resource) sys(sp *space, env *environment) map[string]interface{} {
+	sys := map[string]interface{}{
+		"id":        r.id,
+		"type":      r.kind,
+		"version":   r.version,
+		"createdAt": timestamp(r.createdAt),
+		"updatedAt": timestamp(r.updatedAt),
+	}
+
+	if sp != nil {
+		sys["space"] = link(kindSpace, sp.id)
+	}
+
+	if env != nil {
+		sys["environment"] = link(kindEnvironment, env.id)
+	}
+
+	if r.contentType != "" {
+		sys["contentType"] = link(kindContentType, r.contentType)
+	}
+
+	if r.published != nil {
+		sys["publishedVersion"] = r.publishedVersion
+		sys["publishedCounter"] = r.publishedCounter
+		sys["publishedAt"] = timestamp(r.publishedAt)
+	}
+
+	if !r.firstPublishedAt.IsZero() {
+		sys["firstPublishedAt"] = timestamp(r.firstPublishedAt)
+	}
+
+	if r.archived() {
+		sys["archivedVersion"] = r.archivedVersion
+		sys["archivedAt"] = timestamp(r.archivedAt)
+	}
+
+	return sys
+}
+
+// render returns the draft of the resource as returned by the Management API
+func (r *resource) render(sp *space, env *environment) map[string]interface{} {
+	doc := copyMap(r.doc)
+	doc["sys"] = r.sys(sp, env)
+
+	return doc
+}
+
+// renderPublished returns the published snapshot of the resource as returned
+// by the public endpoints of the Management API
+func (r *resource) renderPublished(sp *space, env *environment) map[string]interface{} {
+	doc := copyMap(r.published)
+	doc["sys"] = r.sys(sp, env)
+
+	return doc
+}
+
+// collection keeps resources in the order they were created
+type collection struct {
+	items map[string]*resource
+	ids   []string
+}
+
+func newCollection() *collection {
+	return &collection{items: map[string]*resource{}}
+}
+
+func (c *collection) get(id string) *resource {
+	return c.items[id]
+}
+
+func (c *collection) add(r *resource) {
+	if _, ok := c.items[r.id]; !ok {
+		c.ids = append(c.ids, r.id)
+	}
+
+	c.items[r.id] = r
+}
+
+func (c *collection) remove(id string) {
+	delete(c.items, id)
+
+	for i, existing := range c.ids {
+		if existing == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+func (c *collection) all() []*resource {
+	resources := make([]*resource, len(c.ids))
+	for i, id := range c.ids {
+		resources[i] = c.items[id]
+	}
+
+	return resources
+}
+
+func (c *collection) clone() *collection {
+	clone := newCollection()
+	for _, r := range c.all() {
+		clone.add(r.clone())
+	}
+
+	return clone
+}
+
+type space struct {
+	*resource
+
+	environments   map[string]*environment
+	environmentIDs []string
+	uploads        map[string]*upload
+}
+
+func (sp *space) addEnvironment(env *environment) {
+	if _, ok := sp.environments[env.id]; !ok {
+		sp.environmentIDs = append(sp.environmentIDs, env.id)
+	}
+
+	sp.environments[env.id] = env
+}
+
+func (sp *space) removeEnvironment(id string) {
+	delete(sp.environments, id)
+
+	for i, existing := range sp.environmentIDs {
+		if existing == id {
+			sp.environmentIDs = append(sp.environmentIDs[:i], sp.environmentIDs[i+1:]...)
+			break
+		}
+	}
+}
+
+type environment struct {
+	*resource
+
+	locales      *collection
+	contentTypes *collection
+	entries      *collection
+	assets       *collection
+
+	// seq is incremented whenever an entry or asset is published or
+	// unpublished
+	seq     int
+	deleted []*deletion
+}
+
+// deletion records that an entry or asset was unpublished, for syncing
+type deletion struct {
+	kind      string
+	id        string
+	seq       int
+	deletedAt time.Time
+	createdAt time.Time
+}
+
+func (env *environment) render(sp *space) map[string]interface{} {
+	doc := env.resource.render(sp, nil)
+	doc["sys"].(map[string]interface{})["status"] = link("Status", "ready")
+
+	return doc
+}
+
+// clone copies the content of the environment into a new environment
+func (env *environment) clone(r *resource) *environment {
+	deleted := make([]*deletion, len(env.deleted))
+	copy(deleted, env.deleted)
+
+	return &environment{
+		resource:     r,
+		locales:      env.locales.clone(),
+		contentTypes: env.contentTypes.clone(),
+		entries:      env.entries.clone(),
+		assets:       env.assets.clone(),
+		seq:          env.seq,
+		deleted:      deleted,
+	}
+}
+
+// locale returns the locale with the code
+func (env *environment) locale(code string) *resource {
+	for _, l := range env.locales.all() {
+		if l.doc["code"] == code {
+			return l
+		}
+	}
+
+	return nil
+}
+
+func (env *environment) defaultLocale() string {
+	for _, l := range env.locales.all() {
+		if isDefault, _ := l.doc["default"].(bool); isDefault {
+			code, _ := l.doc["code"].(string)
+			return code
+		}
+	}
+
+	return DefaultLocale
+}
+
+// localize returns the value of a localized field for the locale, following
+// the fallback chain of the locale
+func (env *environment) localize(values map[string]interface{}, code string) (interface{}, bool) {
+	visited := map[string]bool{}
+
+	for code != "" && !visited[code] {
+		if value, ok := values[code]; ok {
+			return value, true
+		}
+
+		visited[code] = true
+
+		l := env.locale(code)
+		if l == nil {
+			break
+		}
+
+		code, _ = l.doc["fallbackCode"].(string)
+	}
+
+	return nil, false
+}
+
+// publish records that the resource was published or unpublished
+func (env *environment) publish(r *resource, unpublished bool, now time.Time) {
+	if r.kind != kindEntry && r.kind != kindAsset {
+		return
+	}
+
+	env.seq++
+	r.seq = env.seq
+
+	deleted := env.deleted[:0]
+	for _, d := range env.deleted {
+		if d.id != r.id || d.kind != "Deleted"+r.kind {
+			deleted = append(deleted, d)
+		}
+	}
+	env.deleted = deleted
+
+	if unpublished {
+		env.deleted = append(env.deleted, &deletion{
+			kind:      "Deleted" + r.kind,
+			id:        r.id,
+			seq:       env.seq,
+			deletedAt: now,
+			createdAt: r.createdAt,
+		})
+	}
+}
+
+type upload struct {
+	*resource
+
+	data []byte
+}
+
+func (u *upload) render(sp *space) map[string]interface{} {
+	return map[string]interface{}{
+		"sys": map[string]interface{}{
+			"id":        u.id,
+			"type":      kindUpload,
+			"createdAt": timestamp(u.createdAt),
+			"expiresAt": timestamp(u.createdAt.Add(48 * time.Hour)),
+			"space":     link(kindSpace, sp.id),
+		},
+	}
+}
+
+// addSpace creates a space with a master environment and the default locale
+func (s *Server) addSpace(id string, doc map[string]interface{}) *space {
+	if _, ok := s.spaces[id]; !ok {
+		s.spaceIDs = append(s.spaceIDs, id)
+	}
+
+	sp := &space{
+		resource:     s.newResource(kindSpace, id, doc),
+		environments: map[string]*environment{},
+		uploads:      map[string]*upload{},
+	}
+
+	master := &environment{
+		resource:     s.newResource(kindEnvironment, masterEnvironment, map[string]interface{}{"name": masterEnvironment}),
+		locales:      newCollection(),
+		contentTypes: newCollection(),
+		entries:      newCollection(),
+		assets:       newCollection(),
+	}
+
+	master.locales.add(s.newResource(kindLocale, s.newID(kindLocale), map[string]interface{}{
+		"name":                 "English (United States)",
+		"code":                 DefaultLocale,
+		"default":              true,
+		"optional":             false,
+		"contentManagementApi": true,
+		"contentDeliveryApi":   true,
+	}))
+
+	sp.addEnvironment(master)
+	s.spaces[id] = sp
+
+	return sp
+}
+
+func (s *Server) removeSpace(id string) {
+	delete(s.spaces, id)
+
+	for i, existing := range s.spaceIDs {
+		if existing == id {
+			s.spaceIDs = append(s.spaceIDs[:i], s.spaceIDs[i+1:]...)
+			break
+		}
+	}
+}
+
+func link(linkType string, id string) map[string]interface{} {
+	return map[string]interface{}{
+		"sys": map[string]interface{}{
+			"type":     "Link",
+			"linkType": linkType,
+			"id":       id,
+		},
+	}
+}
+
+func timestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func fieldsOf(doc map[string]interface{}) map[string]interface{} {
+	fields, _ := doc["fields"].(map[string]interface{})
+	return fields
+}
+
+// copyMap deep copies a decoded JSON object
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+
+	return copyValue(m).(map[string]interface{})
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			c[key] = copyValue(item)
+		}
+
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, item := range v {
+			c[i] = copyValue(item)
+		}
+
+		return c
+	}
+
+	return value
+}
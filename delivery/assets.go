@@ -8,11 +8,9 @@ import (
 
 // FetchAsset will return the specified asset.
 func (c *Client) FetchAsset(spaceID string, assetID string) (asset *Asset, err error) {
-	c.rl.Wait()
-
 	asset = new(Asset)
 	contentfulError := new(ContentfulError)
-	path := fmt.Sprintf("spaces/%v/assets/%v", spaceID, assetID)
+	path := c.spacePath(spaceID, "assets/%v", assetID)
 	req, err := c.sling.New().
 		Get(path).Request()
 
@@ -22,27 +20,35 @@ func (c *Client) FetchAsset(spaceID string, assetID string) (asset *Asset, err e
 
 	req.URL.RawQuery = q.Encode()
 
-	_, err = c.sling.Do(req, asset, contentfulError)
+	_, err = c.do(req, asset, contentfulError)
 
 	return asset, handleError(err, contentfulError)
 }
 
 // QueryAssets will return all assets associated with a space
 func (c *Client) QueryAssets(spaceID string, params map[string]string, limit int, offset int) (assets []*Asset, pagination *Pagination, err error) {
+	return c.SearchAssets(spaceID, NewQueryFromParams(params), limit, offset)
+}
+
+// SearchAssets will return all assets associated with a space matching the
+// query. All locales are returned unless the query specifies a locale.
+func (c *Client) SearchAssets(spaceID string, query *Query, limit int, offset int) (assets []*Asset, pagination *Pagination, err error) {
 	if spaceID == "" {
 		return nil, nil, fmt.Errorf("FetchAssets failed. Space identifier is not valid!")
 	}
 
+	if query == nil {
+		query = NewQuery()
+	}
+
 	if limit <= 0 {
 		return nil, nil, fmt.Errorf("FetchAssets failed. Limit must be greater than 0")
 	}
 
-	if limit > PaginationSizeLimit {
-		limit = PaginationSizeLimit
+	if limit > c.pageSize {
+		limit = c.pageSize
 	}
 
-	c.rl.Wait()
-
 	type assetsResponse struct {
 		*Pagination
 		Items []*Asset `json:"items"`
@@ -50,7 +56,7 @@ func (c *Client) QueryAssets(spaceID string, params map[string]string, limit int
 
 	results := new(assetsResponse)
 	contentfulError := new(ContentfulError)
-	path := fmt.Sprintf("spaces/%v/assets", spaceID)
+	path := c.spacePath(spaceID, "assets")
 
 	req, err := c.sling.New().
 		Get(path).
@@ -62,16 +68,16 @@ func (c *Client) QueryAssets(spaceID string, params map[string]string, limit int
 
 	// Add query parameters
 	q := req.URL.Query()
-	for k, v := range params {
-		q.Set(k, v)
+	q.Set("locale", "*")
+	for k, v := range query.Values() {
+		q[k] = v
 	}
 
-	q.Set("locale", "*")
 	q.Set("skip", fmt.Sprintf("%v", offset))
 	q.Set("limit", fmt.Sprintf("%v", limit))
 	req.URL.RawQuery = q.Encode()
 
-	_, err = c.sling.Do(req, results, contentfulError)
+	_, err = c.do(req, results, contentfulError)
 
 	return results.Items, results.Pagination, handleError(err, contentfulError)
 }
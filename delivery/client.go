@@ -1,25 +1,42 @@
 package delivery
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	rate "github.com/beefsack/go-rate"
 	"github.com/ingaged/sling"
+
+	"github.com/illyabusigin/contentful/models"
+	"github.com/illyabusigin/contentful/transport"
 )
 
-const baseURL = "https://cdn.contentful.com"
+const (
+	baseURL        = "https://cdn.contentful.com"
+	previewBaseURL = "https://preview.contentful.com"
+)
 
 // PaginationSizeLimit is the sizel limit for pages
 var PaginationSizeLimit = 1000
 
-// A Client manages communication with the Contentful Management API.
+// A Client manages communication with the Contentful Delivery API or, when
+// created with NewPreviewClient, the Contentful Preview API.
 type Client struct {
 	AccessToken string
 
-	sling *sling.Sling
-	rl    *rate.RateLimiter
+	// Preview is true when the client targets the Content Preview API
+	Preview bool
+
+	sling       *sling.Sling
+	doer        Doer
+	middleware  []transport.Middleware
+	rl          *rate.RateLimiter
+	ctx         context.Context
+	logger      transport.StructuredLogger
+	environment string
+	pageSize    int
 }
 
 ////////////////////
@@ -28,6 +45,26 @@ type Client struct {
 
 // NewClient creates a new Contentful API client
 func NewClient(accessToken string, version string, httpClient *http.Client) *Client {
+	return New(accessToken, WithVersion(version), WithHTTPClient(httpClient))
+}
+
+// NewPreviewClient creates a new client for the Contentful Preview API. The
+// Preview API returns the latest draft versions of entries and assets and
+// requires a Content Preview API access token instead of a delivery token.
+// Apart from that it behaves exactly like the Delivery API.
+func NewPreviewClient(accessToken string, version string, httpClient *http.Client) *Client {
+	return New(accessToken, WithVersion(version), WithHTTPClient(httpClient), WithPreview())
+}
+
+// New creates a new Contentful API client configured by the provided options.
+// Clients don't share any configuration, so differently configured clients can
+// be used side by side.
+func New(accessToken string, opts ...Option) *Client {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	type Params struct {
 		AccessToken string `url:"access_token,omitempty"`
 		Locale      string `url:"locale,omitempty"`
@@ -37,16 +74,148 @@ func NewClient(accessToken string, version string, httpClient *http.Client) *Cli
 
 	client := &Client{
 		AccessToken: accessToken,
-		sling: sling.New().Client(httpClient).Base(baseURL).
-			Set("Content-Type", contentTypeHeader(version)).
+		Preview:     o.preview,
+		doer:        o.doer,
+		middleware:  o.middleware,
+		logger:      o.logger,
+		environment: o.environment,
+		pageSize:    o.pageSize,
+		sling: sling.New().Base(o.baseURL).
+			Set("Content-Type", contentTypeHeader(o.version)).
 			QueryStruct(params),
 	}
 
-	client.rl = rate.New(10, time.Second*1)
+	if o.userAgent != "" {
+		client.sling.Set("User-Agent", o.userAgent)
+	}
+
+	if o.rateLimit > 0 {
+		client.rl = rate.New(o.rateLimit, o.rateInterval)
+	}
+
+	client.SetRetryPolicy(o.retryPolicy)
 
 	return client
 }
 
+// SetRetryPolicy configures how requests are retried when Contentful responds
+// with a rate limit or server error. Requests are retried using the
+// transport.DefaultRetryPolicy unless configured otherwise, a nil policy
+// disables retries. The middleware configured with WithMiddleware wraps the
+// retries, so it sees every request once.
+func (c *Client) SetRetryPolicy(policy *transport.RetryPolicy) {
+	c.sling = c.sling.New().Doer(transport.Chain(transport.Retry(c.doer, policy), c.middleware...))
+}
+
+// Environment returns the identifier of the environment the client operates
+// on. An empty identifier refers to the master environment.
+func (c *Client) Environment() string {
+	return c.environment
+}
+
+// spacePath returns the path of a resource within a space, scoped to the
+// client's environment.
+func (c *Client) spacePath(spaceID string, format string, args ...interface{}) string {
+	resource := fmt.Sprintf(format, args...)
+	if c.environment == "" {
+		return fmt.Sprintf("spaces/%v/%v", spaceID, resource)
+	}
+
+	return fmt.Sprintf("spaces/%v/environments/%v/%v", spaceID, c.environment, resource)
+}
+
+// InEnvironment returns a shallow copy of the client whose entries, assets,
+// content types and locales are scoped to the given environment. The
+// identifier can also be an environment alias.
+//
+//	staging := client.InEnvironment("staging")
+//	entry, err := staging.FetchEntry(spaceID, entryID)
+func (c *Client) InEnvironment(environmentID string) *Client {
+	client := *c
+	client.environment = environmentID
+
+	return &client
+}
+
+// WithContext returns a shallow copy of the client whose requests are bound to
+// ctx. Cancelling ctx aborts rate limit waits as well as in-flight requests.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+
+	client := *c
+	client.ctx = ctx
+
+	return &client
+}
+
+// Context returns the context the client's requests are bound to. It defaults
+// to context.Background.
+func (c *Client) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+
+	return context.Background()
+}
+
+// wait blocks until the rate limiter permits another request or the client's
+// context is done.
+func (c *Client) wait() error {
+	ctx := c.Context()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if c.rl == nil {
+			return nil
+		}
+
+		ok, remaining := c.rl.Try()
+		if ok {
+			return nil
+		}
+
+		timer := time.NewTimer(remaining)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// do performs the request once the rate limiter permits it. The request is
+// bound to the client's context. Failed responses are decoded into failure
+// along with the status code and rate limit information, bodies that can't be
+// decoded are ignored.
+func (c *Client) do(req *http.Request, success interface{}, failure *ContentfulError) (*http.Response, error) {
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := c.sling.Do(req.WithContext(c.Context()), success, failure)
+
+	transport.LogRequest(c.Context(), c.logger, req, resp, time.Since(start), err)
+
+	if resp != nil && resp.StatusCode >= http.StatusBadRequest && failure != nil {
+		err = nil
+		failure.StatusCode = resp.StatusCode
+		failure.RateLimitReset, _ = transport.RetryAfter(resp)
+
+		if failure.RequestID == "" {
+			failure.RequestID = resp.Header.Get("X-Contentful-Request-Id")
+		}
+	}
+
+	return resp, err
+}
+
 func contentTypeHeader(version string) string {
 	return fmt.Sprintf("application/vnd.contentful.delivery.%v+json", version)
 }
@@ -60,45 +229,18 @@ func handleError(reqErr error, err *ContentfulError) error {
 		return reqErr
 	}
 
-	if err.RequestID == "" && err.Message == "" {
+	if err.RequestID == "" && err.Message == "" && err.StatusCode < http.StatusBadRequest {
 		return nil
 	}
 
 	return err
 }
 
-// ContentError is the error object for errors that get returned as party of entry queries
-type ContentError struct {
-	Details struct {
-		ID       string `json:"id"`
-		Type     string `json:"type"`
-		LinkType string `json:"linkType"`
-	} `json:"details"`
-	Sys struct {
-		Type string `json:"type"`
-		ID   string `json:"id"`
-	} `json:"sys"`
-}
-
-func (e *ContentError) Error() string {
-	return fmt.Sprintf("Error: %v", e.Sys.ID)
-}
-
-// ContentfulError represnts the error object that is returned when something
-// goes wrong with a Contentful API request. This struct conforms to the `error`
-// interface.
-type ContentfulError struct {
-	RequestID string `json:"requestId"`
-	Message   string `json:"message"`
-	Sys       struct {
-		Type string `json:"type"`
-		ID   string `json:"id"`
-	} `json:"sys"`
-}
-
-func (e *ContentfulError) Error() string {
-	return fmt.Sprintf("%v, %v, %v", e.Message, e.RequestID, e.Sys)
-}
+// ContentfulError is returned when a Contentful API request fails. Use
+// errors.Is with the sentinel errors of the models package, such as
+// models.ErrNotFound, to tell failures apart and errors.As to access the status
+// code and request ID.
+type ContentfulError = models.Error
 
 // Doer executes http requests.  It is implemented by *http.Client.  You can
 // wrap *http.Client with layers of Doers to form a stack of client-side
@@ -0,0 +1,72 @@
+package delivery
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	assert.NotNil(t, client, "Client should not be nil")
+	assert.NotNil(t, client.rl)
+	assert.False(t, client.Preview)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	_, err := client.FetchEntry("space123", "entry123")
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, "cdn.contentful.com", doer.request.URL.Host)
+}
+
+func TestNewPreviewClient(t *testing.T) {
+	previewToken := "preview_token"
+	client := NewPreviewClient(previewToken, version, nil)
+
+	assert.NotNil(t, client, "Client should not be nil")
+	assert.True(t, client.Preview)
+	assert.Equal(t, previewToken, client.AccessToken)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	_, err := client.FetchEntry("space123", "entry123")
+	req := doer.request
+
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, "https://preview.contentful.com/spaces/space123/entries/entry123", req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+	assert.Equal(t, previewToken, req.URL.Query().Get("access_token"))
+}
+
+func TestWithPreview(t *testing.T) {
+	client := New("preview_token", WithPreview(), WithRateLimit(0, 0))
+	assert.True(t, client.Preview)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	_, err := client.FetchEntry("space123", "entry123")
+	req := doer.request
+
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, "preview.contentful.com", req.URL.Host)
+	assert.Equal(t, "preview_token", req.URL.Query().Get("access_token"))
+
+	// Other clients still use the Delivery API
+	other := New(accessToken, WithRateLimit(0, 0))
+	assert.False(t, other.Preview)
+	other.sling = other.sling.New().Doer(doer)
+
+	_, err = other.FetchEntry("space123", "entry123")
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, "cdn.contentful.com", doer.request.URL.Host)
+	assert.Equal(t, accessToken, doer.request.URL.Query().Get("access_token"))
+}
@@ -21,8 +21,6 @@ func (c *Client) FetchContentTypes(spaceID string, limit int, offset int) (conte
 		limit = 100
 	}
 
-	c.rl.Wait()
-
 	type contentTypesResponse struct {
 		*Pagination
 		Items []*ContentType `json:"items"`
@@ -30,7 +28,7 @@ func (c *Client) FetchContentTypes(spaceID string, limit int, offset int) (conte
 
 	results := new(contentTypesResponse)
 	contentfulError := new(ContentfulError)
-	path := fmt.Sprintf("spaces/%v/content_types", spaceID)
+	path := c.spacePath(spaceID, "content_types")
 
 	req, err := c.sling.New().
 		Get(path).Request()
@@ -45,7 +43,7 @@ func (c *Client) FetchContentTypes(spaceID string, limit int, offset int) (conte
 	q.Set("limit", fmt.Sprintf("%v", limit))
 	req.URL.RawQuery = q.Encode()
 
-	_, err = c.sling.Do(req, results, contentfulError)
+	_, err = c.do(req, results, contentfulError)
 
 	return results.Items, results.Pagination, handleError(err, contentfulError)
 }
@@ -58,12 +56,16 @@ func (c *Client) FetchContentType(spaceID string, contentTypeID string) (content
 		return
 	}
 
-	c.rl.Wait()
-
 	contentType = new(ContentType)
 	contentfulError := new(ContentfulError)
-	path := fmt.Sprintf("spaces/%v/content_types/%v", spaceID, contentTypeID)
-	_, err = c.sling.New().Get(path).Receive(contentType, contentfulError)
+	path := c.spacePath(spaceID, "content_types/%v", contentTypeID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, contentType, contentfulError)
 
 	return contentType, handleError(err, contentfulError)
 }
@@ -4,10 +4,16 @@ import (
 	"fmt"
 
 	. "github.com/illyabusigin/contentful/models"
+	"github.com/illyabusigin/contentful/transport"
 )
 
 // QueryEntries returns all entries for the given space and parameters.
 func (c *Client) QueryEntries(spaceID string, params map[string]string, limit int, offset int) (result *QueryEntriesResult) {
+	return c.SearchEntries(spaceID, NewQueryFromParams(params), limit, offset)
+}
+
+// SearchEntries returns all entries for the given space matching the query.
+func (c *Client) SearchEntries(spaceID string, query *Query, limit int, offset int) (result *QueryEntriesResult) {
 	result = &QueryEntriesResult{
 		Entries: []*Entry{},
 		Includes: &Includes{
@@ -23,17 +29,19 @@ func (c *Client) QueryEntries(spaceID string, params map[string]string, limit in
 		return
 	}
 
+	if query == nil {
+		query = NewQuery()
+	}
+
 	if limit < 0 {
 		result.Errors = append(result.Errors, fmt.Errorf("QueryEntries failed. Limit must be greater than 0"))
 		return
 	}
 
-	if limit > PaginationSizeLimit {
-		limit = PaginationSizeLimit
+	if limit > c.pageSize {
+		limit = c.pageSize
 	}
 
-	c.rl.Wait()
-
 	type entriesResponse struct {
 		*Pagination
 		Items    []*Entry        `json:"items"`
@@ -50,7 +58,7 @@ func (c *Client) QueryEntries(spaceID string, params map[string]string, limit in
 	response.Errors = []*ContentError{}
 
 	contentfulError := new(ContentfulError)
-	path := fmt.Sprintf("spaces/%v/entries", spaceID)
+	path := c.spacePath(spaceID, "entries")
 	req, err := c.sling.New().
 		Get(path).
 		Request()
@@ -61,8 +69,8 @@ func (c *Client) QueryEntries(spaceID string, params map[string]string, limit in
 
 	// Add query parameters
 	q := req.URL.Query()
-	for k, v := range params {
-		q.Set(k, v)
+	for k, v := range query.Values() {
+		q[k] = v
 	}
 
 	q.Set("skip", fmt.Sprintf("%v", offset))
@@ -70,14 +78,17 @@ func (c *Client) QueryEntries(spaceID string, params map[string]string, limit in
 	req.URL.RawQuery = q.Encode()
 
 	// Perform request
-	_, err = c.sling.Do(req, response, contentfulError)
+	_, err = c.do(req, response, contentfulError)
 
 	result.Pagination = response.Pagination
 	result.Includes = response.Includes
 	result.Entries = response.Items
 
 	coercedErrors := []error{}
-	fmt.Println("found errors in contentful layer:", len(response.Errors))
+	if len(response.Errors) > 0 && c.logger != nil {
+		c.logger.Log(c.Context(), transport.LevelDebug, "contentful query errors", transport.Field{Key: "errors", Value: len(response.Errors)})
+	}
+
 	for _, err := range response.Errors {
 		coercedErrors = append(coercedErrors, err)
 	}
@@ -97,12 +108,26 @@ func (c *Client) FetchEntry(spaceID string, entryID string) (entry *Entry, err e
 		return
 	}
 
-	c.rl.Wait()
-
 	entry = new(Entry)
 	contentfulError := new(ContentfulError)
-	path := fmt.Sprintf("spaces/%v/entries/%v", spaceID, entryID)
-	_, err = c.sling.New().Get(path).Receive(entry, contentfulError)
+	path := c.spacePath(spaceID, "entries/%v", entryID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, entry, contentfulError)
 
 	return entry, handleError(err, contentfulError)
 }
+
+// ResolveLinks replaces the links inside the fields of the result entries with
+// the matching *Entry and *Asset from the result includes, following links up
+// to the given include depth. Links that cannot be resolved are added to
+// result.Errors as notResolvable ContentErrors unless Contentful already
+// reported them. Resolved results with cyclic links cannot be marshalled, see
+// QueryEntriesResult.ResolveLinks.
+func ResolveLinks(result *QueryEntriesResult, depth int) {
+	result.ResolveLinks(depth)
+}
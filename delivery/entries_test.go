@@ -0,0 +1,88 @@
+package delivery
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/illyabusigin/contentful/models"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestResolveLinks(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	client.sling = client.sling.New().Doer(doer)
+
+	doer.response = &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/vnd.contentful.delivery.v1+json"}},
+		Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "total": 1,
+    "skip": 0,
+    "limit": 100,
+    "items": [{
+        "sys": {"id": "cat", "type": "Entry"},
+        "fields": {
+            "friends": {"en-US": [
+                {"sys": {"type": "Link", "linkType": "Entry", "id": "dog"}},
+                {"sys": {"type": "Link", "linkType": "Entry", "id": "mouse"}},
+                {"sys": {"type": "Link", "linkType": "Entry", "id": "bird"}}
+            ]},
+            "image": {"en-US": {"sys": {"type": "Link", "linkType": "Asset", "id": "catImage"}}}
+        }
+    }],
+    "includes": {
+        "Entry": [{
+            "sys": {"id": "dog", "type": "Entry"},
+            "fields": {"bestFriend": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "cat"}}}}
+        }],
+        "Asset": [{
+            "sys": {"id": "catImage", "type": "Asset"},
+            "fields": {"title": {"en-US": "Cat"}}
+        }]
+    },
+    "errors": [{
+        "sys": {"id": "notResolvable", "type": "error"},
+        "details": {"type": "Link", "linkType": "Entry", "id": "mouse"}
+    }]
+}`)),
+	}
+
+	result := client.QueryEntries("space123", nil, 100, 0)
+	assert.Len(t, result.Errors, 1)
+	assert.Len(t, result.Entries, 1)
+
+	ResolveLinks(result, 2)
+
+	cat := result.Entries[0]
+	friends := cat.Fields["friends"].(map[string]interface{})["en-US"].([]interface{})
+	dog, ok := friends[0].(*Entry)
+	assert.True(t, ok, "Link to dog should be resolved")
+	assert.Equal(t, "dog", dog.ID)
+
+	_, ok = cat.Fields["image"].(map[string]interface{})["en-US"].(*Asset)
+	assert.True(t, ok, "Link to asset should be resolved")
+
+	// Cycles resolve to the same entry
+	assert.Equal(t, cat, dog.Fields["bestFriend"].(map[string]interface{})["en-US"])
+
+	// Unresolvable links are left in place and reported once, with the same
+	// error type as the errors returned by Contentful
+	_, ok = friends[1].(map[string]interface{})
+	assert.True(t, ok, "Link to mouse should not be resolved")
+	assert.Len(t, result.Errors, 2)
+
+	ids := []string{}
+	for _, err := range result.Errors {
+		contentError, ok := err.(*ContentError)
+		assert.True(t, ok)
+		assert.Equal(t, "notResolvable", contentError.Sys.ID)
+		ids = append(ids, contentError.Details.ID)
+	}
+	assert.Equal(t, []string{"mouse", "bird"}, ids)
+}
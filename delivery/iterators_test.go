@@ -0,0 +1,100 @@
+package delivery
+
+import (
+	"testing"
+
+	. "github.com/illyabusigin/contentful/models"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestEntryIterator(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	doer := &sequenceDoer{bodies: []string{
+		`{"total": 3, "skip": 0, "limit": 2, "items": [{"sys": {"id": "1"}}, {"sys": {"id": "2"}}], "includes": {"Asset": [{"sys": {"id": "a"}}]},
+			"errors": [{"sys": {"type": "error", "id": "notResolvable"}, "details": {"type": "Link", "linkType": "Entry", "id": "missing"}}]}`,
+		`{"total": 3, "skip": 2, "limit": 2, "items": [{"sys": {"id": "3"}}], "includes": {"Asset": [{"sys": {"id": "a"}}, {"sys": {"id": "b"}}]}}`,
+	}}
+	client.sling = client.sling.New().Doer(doer)
+
+	it := client.IterateEntries("space123", NewQuery().ContentType("post"))
+	it.pager.PageSize = 2
+
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, it.Entry().ID)
+	}
+
+	assert.Nil(t, it.Err(), "ContentErrors should not stop the iteration")
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Len(t, doer.requests, 2)
+	assert.Equal(t, "0", doer.requests[0].URL.Query().Get("skip"))
+	assert.Equal(t, "2", doer.requests[1].URL.Query().Get("skip"))
+	assert.Equal(t, "post", doer.requests[1].URL.Query().Get("content_type"))
+	assert.Len(t, it.Includes().Assets, 2, "Includes should be merged across pages")
+	assert.Len(t, it.Errors(), 1)
+	assert.Equal(t, "missing", it.Errors()[0].(*ContentError).Details.ID)
+	assert.False(t, it.Next())
+}
+
+func TestEntryIteratorError(t *testing.T) {
+	// The second page fails
+	doer := &sequenceDoer{bodies: []string{
+		`{"total": 3, "skip": 0, "limit": 2, "items": [{"sys": {"id": "1"}}, {"sys": {"id": "2"}}]}`,
+	}}
+	client := New(accessToken, WithDoer(doer), WithRetryPolicy(nil), WithRateLimit(0, 0))
+
+	it := client.IterateEntries("space123", nil)
+	it.pager.PageSize = 2
+
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, it.Entry().ID)
+	}
+
+	assert.Equal(t, []string{"1", "2"}, ids)
+	assert.Equal(t, errIntercept, it.Err())
+	assert.Nil(t, it.Entry())
+	assert.False(t, it.Next())
+}
+
+func TestAssetIteratorChan(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	doer := &sequenceDoer{bodies: []string{
+		`{"total": 3, "skip": 0, "limit": 2, "items": [{"sys": {"id": "1"}}, {"sys": {"id": "2"}}]}`,
+		`{"total": 3, "skip": 2, "limit": 2, "items": [{"sys": {"id": "3"}}]}`,
+	}}
+	client.sling = client.sling.New().Doer(doer)
+
+	it := client.IterateAssets("space123", nil)
+	it.pager.PageSize = 2
+
+	ids := []string{}
+	for asset := range it.Chan(nil) {
+		ids = append(ids, asset.ID)
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Len(t, doer.requests, 2)
+}
+
+func TestContentTypeIteratorChanError(t *testing.T) {
+	// The second page fails, the channel is closed and Err reports why
+	doer := &sequenceDoer{bodies: []string{
+		`{"total": 150, "skip": 0, "limit": 100, "items": [{"sys": {"id": "post"}, "name": "Post"}]}`,
+	}}
+	client := New(accessToken, WithDoer(doer), WithRetryPolicy(nil), WithRateLimit(0, 0))
+
+	it := client.IterateContentTypes("space123")
+	names := []string{}
+	for contentType := range it.Chan(nil) {
+		names = append(names, contentType.Name)
+	}
+
+	assert.Equal(t, []string{"Post"}, names)
+	assert.Equal(t, errIntercept, it.Err())
+}
@@ -1 +1,32 @@
 package delivery
+
+import (
+	"fmt"
+
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// FetchLocales returns all locales of the provided space
+func (c *Client) FetchLocales(spaceID string) (locales []*Locale, pagination *Pagination, err error) {
+	if spaceID == "" {
+		return nil, nil, fmt.Errorf("FetchLocales failed. Space identifier is not valid!")
+	}
+
+	type localesResponse struct {
+		*Pagination
+		Items []*Locale `json:"items"`
+	}
+
+	results := new(localesResponse)
+	contentfulError := new(ContentfulError)
+	path := c.spacePath(spaceID, "locales")
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, results, contentfulError)
+
+	return results.Items, results.Pagination, handleError(err, contentfulError)
+}
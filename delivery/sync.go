@@ -0,0 +1,173 @@
+package delivery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// InitialSync performs an initial synchronization of the space, returning all
+// published entries and assets. Additional params such as "type" or
+// "content_type" can be used to restrict the items that are synchronized.
+// Every page of the sync is followed until a NextSyncToken is returned.
+func (c *Client) InitialSync(spaceID string, params map[string]string) (result *SyncResult, err error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("InitialSync failed. Space identifier is not valid!")
+	}
+
+	q := url.Values{}
+	for k, v := range params {
+		q.Set(k, v)
+	}
+
+	q.Set("initial", "true")
+
+	return c.sync(spaceID, q)
+}
+
+// Sync performs a delta synchronization using a token returned by a previous
+// sync. Only the items created, updated or deleted since then are returned.
+// The Preview API only supports initial syncs.
+func (c *Client) Sync(spaceID string, syncToken string) (result *SyncResult, err error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("Sync failed. Space identifier is not valid!")
+	}
+
+	if c.Preview {
+		return nil, fmt.Errorf("Sync failed. Delta syncs are not supported by the Preview API!")
+	}
+
+	if syncToken == "" {
+		return nil, fmt.Errorf("Sync failed. Sync token cannot be empty!")
+	}
+
+	q := url.Values{}
+	q.Set("sync_token", syncToken)
+
+	return c.sync(spaceID, q)
+}
+
+// SyncToken extracts the sync token from a nextSyncUrl or nextPageUrl.
+func SyncToken(syncURL string) (string, error) {
+	u, err := url.Parse(syncURL)
+	if err != nil {
+		return "", err
+	}
+
+	token := u.Query().Get("sync_token")
+	if token == "" {
+		return "", fmt.Errorf("SyncToken failed. URL does not contain a sync token: %v", syncURL)
+	}
+
+	return token, nil
+}
+
+func (c *Client) sync(spaceID string, params url.Values) (result *SyncResult, err error) {
+	type syncResponse struct {
+		Items       []json.RawMessage `json:"items"`
+		NextPageURL string            `json:"nextPageUrl"`
+		NextSyncURL string            `json:"nextSyncUrl"`
+	}
+
+	result = &SyncResult{
+		Entries:        []*Entry{},
+		Assets:         []*Asset{},
+		DeletedEntries: []*DeletedItem{},
+		DeletedAssets:  []*DeletedItem{},
+	}
+
+	path := c.spacePath(spaceID, "sync")
+
+	for {
+		response := new(syncResponse)
+		contentfulError := new(ContentfulError)
+		req, err := c.sling.New().
+			Get(path).
+			Request()
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The Sync API always returns all locales and rejects the locale
+		// parameter that is set for every other request.
+		q := req.URL.Query()
+		q.Del("locale")
+		for k, v := range params {
+			q[k] = v
+		}
+
+		req.URL.RawQuery = q.Encode()
+
+		_, err = c.do(req, response, contentfulError)
+		if err = handleError(err, contentfulError); err != nil {
+			return nil, err
+		}
+
+		for _, item := range response.Items {
+			if err = addSyncItem(result, item); err != nil {
+				return nil, err
+			}
+		}
+
+		if response.NextPageURL == "" {
+			result.NextSyncURL = response.NextSyncURL
+			result.NextSyncToken, err = SyncToken(response.NextSyncURL)
+
+			return result, err
+		}
+
+		token, err := SyncToken(response.NextPageURL)
+		if err != nil {
+			return nil, err
+		}
+
+		params = url.Values{}
+		params.Set("sync_token", token)
+	}
+}
+
+// addSyncItem decodes a single sync item into its typed representation
+func addSyncItem(r *SyncResult, item json.RawMessage) error {
+	var header struct {
+		Sys struct {
+			Type string `json:"type"`
+		} `json:"sys"`
+	}
+
+	if err := json.Unmarshal(item, &header); err != nil {
+		return err
+	}
+
+	switch header.Sys.Type {
+	case "Entry":
+		entry := new(Entry)
+		if err := json.Unmarshal(item, entry); err != nil {
+			return err
+		}
+
+		r.Entries = append(r.Entries, entry)
+	case "Asset":
+		asset := new(Asset)
+		if err := json.Unmarshal(item, asset); err != nil {
+			return err
+		}
+
+		r.Assets = append(r.Assets, asset)
+	case DeletedEntryType, DeletedAssetType:
+		deleted := new(DeletedItem)
+		if err := json.Unmarshal(item, deleted); err != nil {
+			return err
+		}
+
+		if header.Sys.Type == DeletedEntryType {
+			r.DeletedEntries = append(r.DeletedEntries, deleted)
+		} else {
+			r.DeletedAssets = append(r.DeletedAssets, deleted)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package delivery
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+type interceptor struct {
+	request  *http.Request
+	response *http.Response
+	err      error
+}
+
+func (i *interceptor) Do(req *http.Request) (*http.Response, error) {
+	i.request = req
+
+	if i.response != nil {
+		i.response.Request = req
+	}
+
+	return i.response, i.err
+}
+
+// sequenceDoer returns a new response for every request and fails with
+// errIntercept once all bodies are used
+type sequenceDoer struct {
+	requests []*http.Request
+	bodies   []string
+}
+
+func (d *sequenceDoer) Do(req *http.Request) (*http.Response, error) {
+	d.requests = append(d.requests, req)
+	if len(d.bodies) == 0 {
+		return nil, errIntercept
+	}
+
+	body := d.bodies[0]
+	d.bodies = d.bodies[1:]
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/vnd.contentful.delivery.v1+json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Request:    req,
+	}, nil
+}
+
+var (
+	accessToken  = "access_token"
+	version      = "v1"
+	errIntercept = fmt.Errorf("Intercept error")
+)
+
+func TestInitialSyncRequest(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	_, err := client.InitialSync("space123", map[string]string{"type": "Entry"})
+	req := doer.request
+
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, "GET", req.Method)
+	assert.Equal(t, "/spaces/space123/sync", req.URL.Path)
+	assert.Equal(t, "true", req.URL.Query().Get("initial"))
+	assert.Equal(t, "Entry", req.URL.Query().Get("type"))
+	assert.Equal(t, accessToken, req.URL.Query().Get("access_token"))
+	assert.NotContains(t, req.URL.Query(), "locale", "The Sync API rejects the locale parameter")
+	assert.NotContains(t, req.URL.Query(), "sync_token")
+}
+
+func TestSyncRequest(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	_, err := client.Sync("space123", "token123")
+	req := doer.request
+
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, "/spaces/space123/sync", req.URL.Path)
+	assert.Equal(t, "token123", req.URL.Query().Get("sync_token"))
+	assert.NotContains(t, req.URL.Query(), "initial")
+	assert.NotContains(t, req.URL.Query(), "locale")
+}
+
+func TestSyncValidationFailures(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	_, err := client.InitialSync("", nil)
+	assert.EqualError(t, err, "InitialSync failed. Space identifier is not valid!")
+
+	_, err = client.Sync("", "token123")
+	assert.EqualError(t, err, "Sync failed. Space identifier is not valid!")
+
+	_, err = client.Sync("space123", "")
+	assert.EqualError(t, err, "Sync failed. Sync token cannot be empty!")
+
+	// The Preview API only supports initial syncs
+	preview := NewPreviewClient(accessToken, version, nil)
+	preview.sling = preview.sling.New().Doer(doer)
+
+	_, err = preview.Sync("space123", "token123")
+	assert.EqualError(t, err, "Sync failed. Delta syncs are not supported by the Preview API!")
+
+	assert.Nil(t, doer.request, "No request should be performed")
+}
+
+func TestSyncPages(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	doer := &sequenceDoer{bodies: []string{
+		`{
+			"items": [{"sys": {"type": "Entry", "id": "entry1"}, "fields": {"title": {"en-US": "Hello"}}}],
+			"nextPageUrl": "https://cdn.contentful.com/spaces/space123/sync?sync_token=page2"
+		}`,
+		`{
+			"items": [
+				{"sys": {"type": "Asset", "id": "asset1"}},
+				{"sys": {"type": "DeletedEntry", "id": "entry2"}},
+				{"sys": {"type": "DeletedAsset", "id": "asset2"}}
+			],
+			"nextSyncUrl": "https://cdn.contentful.com/spaces/space123/sync?sync_token=next"
+		}`,
+	}}
+	client.sling = client.sling.New().Doer(doer)
+
+	result, err := client.InitialSync("space123", nil)
+	assert.Nil(t, err)
+
+	assert.Len(t, doer.requests, 2)
+	assert.Equal(t, "true", doer.requests[0].URL.Query().Get("initial"))
+	assert.Equal(t, "page2", doer.requests[1].URL.Query().Get("sync_token"))
+	assert.NotContains(t, doer.requests[1].URL.Query(), "initial", "Following pages are fetched by token only")
+
+	assert.Len(t, result.Entries, 1)
+	assert.Equal(t, "entry1", result.Entries[0].ID)
+	assert.Len(t, result.Assets, 1)
+	assert.Equal(t, "asset1", result.Assets[0].ID)
+	assert.Len(t, result.DeletedEntries, 1)
+	assert.Equal(t, "entry2", result.DeletedEntries[0].ID)
+	assert.Len(t, result.DeletedAssets, 1)
+	assert.Equal(t, "asset2", result.DeletedAssets[0].ID)
+
+	assert.Equal(t, "https://cdn.contentful.com/spaces/space123/sync?sync_token=next", result.NextSyncURL)
+	assert.Equal(t, "next", result.NextSyncToken)
+}
+
+func TestSyncToken(t *testing.T) {
+	token, err := SyncToken("https://cdn.contentful.com/spaces/space123/sync?sync_token=w5ZGw6JFwqZmVcKsE8Kow4grw45QdybC")
+	assert.Nil(t, err)
+	assert.Equal(t, "w5ZGw6JFwqZmVcKsE8Kow4grw45QdybC", token)
+
+	// nextPageUrl carries the token the same way
+	token, err = SyncToken("https://cdn.contentful.com/spaces/space123/environments/staging/sync?sync_token=page2&access_token=abc")
+	assert.Nil(t, err)
+	assert.Equal(t, "page2", token)
+
+	_, err = SyncToken("https://cdn.contentful.com/spaces/space123/sync")
+	assert.EqualError(t, err, "SyncToken failed. URL does not contain a sync token: https://cdn.contentful.com/spaces/space123/sync")
+
+	_, err = SyncToken("%zz")
+	assert.NotNil(t, err)
+}
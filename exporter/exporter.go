@@ -0,0 +1,340 @@
+// Package exporter snapshots a space through the Content Management API. The
+// export uses the JSON format of the contentful-export tool:
+//
+//	{
+//	  "contentTypes": [...],
+//	  "locales": [...],
+//	  "entries": [...],
+//	  "assets": [...]
+//	}
+//
+// Every item is written as returned by the Content Management API, including
+// drafts and archived items. Their sys properties record whether they are
+// published or archived. Entries and assets are fetched and written one page
+// at a time, so large spaces are never held in memory.
+//
+//	summary, err := exporter.ExportDir(client, spaceID, "backup", &exporter.Options{
+//		DownloadAssets: true,
+//	})
+package exporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+	"github.com/illyabusigin/contentful/transport"
+)
+
+// Data is the content of an export file
+type Data struct {
+	ContentTypes []*ContentType `json:"contentTypes"`
+	Locales      []*Locale      `json:"locales"`
+	Entries      []*Entry       `json:"entries"`
+	Assets       []*Asset       `json:"assets"`
+}
+
+// Load reads an export file
+func Load(path string) (*Data, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data := new(Data)
+	if err = json.NewDecoder(bufio.NewReader(f)).Decode(data); err != nil {
+		return nil, fmt.Errorf("Load failed. %v: %v", path, err)
+	}
+
+	return data, nil
+}
+
+// Options configures an export
+type Options struct {
+	// EntryQuery restricts the exported entries, for example to a single
+	// content type. All entries are exported by default.
+	EntryQuery *Query
+	// SkipContentModel skips content types and locales
+	SkipContentModel bool
+	// SkipContent skips entries and assets
+	SkipContent bool
+
+	// DownloadAssets downloads the files of processed assets next to the
+	// export file. It is only used by ExportDir.
+	DownloadAssets bool
+	// Doer downloads asset files. Defaults to http.DefaultClient.
+	Doer transport.Doer
+}
+
+func (o *Options) withDefaults() *Options {
+	options := Options{}
+	if o != nil {
+		options = *o
+	}
+
+	if options.Doer == nil {
+		options.Doer = http.DefaultClient
+	}
+
+	return &options
+}
+
+// Summary counts the exported items
+type Summary struct {
+	// Path is the export file written by ExportDir
+	Path string
+
+	ContentTypes int
+	Locales      int
+	Entries      int
+	Assets       int
+	// Files is the number of downloaded asset files
+	Files int
+}
+
+// FileName returns the name of the export file written by ExportDir
+func FileName(spaceID string) string {
+	return fmt.Sprintf("contentful-export-%v.json", spaceID)
+}
+
+// Export writes the space as a single JSON document to w. Asset files are not
+// downloaded.
+func Export(client *management.Client, spaceID string, w io.Writer, options *Options) (*Summary, error) {
+	options = options.withDefaults()
+	options.DownloadAssets = false
+
+	e := &export{
+		client:  client,
+		spaceID: spaceID,
+		options: options,
+		summary: &Summary{},
+	}
+
+	return e.summary, e.run(w)
+}
+
+// ExportDir writes the space to the export file in dir, see FileName. Asset
+// files are downloaded to dir if enabled, using the host and path of their
+// URL like contentful-export:
+//
+//	backup/images.ctfassets.net/<space>/<asset>/<token>/photo.jpg
+func ExportDir(client *management.Client, spaceID string, dir string, options *Options) (*Summary, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, FileName(spaceID))
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &export{
+		client:  client,
+		spaceID: spaceID,
+		dir:     dir,
+		options: options.withDefaults(),
+		summary: &Summary{Path: path},
+	}
+
+	err = e.run(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return e.summary, err
+}
+
+type export struct {
+	client  *management.Client
+	spaceID string
+	dir     string
+	options *Options
+	summary *Summary
+}
+
+func (e *export) run(w io.Writer) error {
+	if e.spaceID == "" {
+		return fmt.Errorf("Export failed. Space identifier is not valid!")
+	}
+
+	out := newWriter(w)
+
+	if !e.options.SkipContentModel {
+		out.begin("contentTypes")
+		it := e.client.IterateContentTypes(e.spaceID, false)
+		for it.Next() {
+			out.item(it.ContentType())
+			e.summary.ContentTypes++
+		}
+		out.end()
+
+		if err := it.Err(); err != nil {
+			return fmt.Errorf("Export failed. Content types: %w", err)
+		}
+
+		out.begin("locales")
+		locales := e.client.IterateLocales(e.spaceID)
+		for locales.Next() {
+			out.item(locales.Locale())
+			e.summary.Locales++
+		}
+		out.end()
+
+		if err := locales.Err(); err != nil {
+			return fmt.Errorf("Export failed. Locales: %w", err)
+		}
+	}
+
+	if !e.options.SkipContent {
+		if err := e.entries(out); err != nil {
+			return err
+		}
+
+		if err := e.assets(out); err != nil {
+			return err
+		}
+	}
+
+	return out.close()
+}
+
+// entries exports the entries matching the entry query in a stable order
+func (e *export) entries(out *writer) error {
+	// Copy the query so the caller's query isn't modified
+	query := NewQuery()
+	if e.options.EntryQuery != nil {
+		for key, values := range e.options.EntryQuery.Values() {
+			for _, value := range values {
+				query.Param(key, value)
+			}
+		}
+	}
+
+	// A stable order keeps pages from overlapping
+	if query.Values().Get("order") == "" {
+		query.Order("sys.createdAt", "sys.id")
+	}
+
+	out.begin("entries")
+	defer out.end()
+
+	it := e.client.IterateEntries(e.spaceID, query)
+	for it.Next() {
+		out.item(it.Entry())
+		e.summary.Entries++
+	}
+
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("Export failed. Entries: %w", err)
+	}
+
+	return out.err
+}
+
+func (e *export) assets(out *writer) error {
+	out.begin("assets")
+	defer out.end()
+
+	it := e.client.IterateAssets(e.spaceID, false, NewQuery().Order("sys.createdAt", "sys.id"))
+	for it.Next() {
+		asset := it.Asset()
+		out.item(asset)
+		e.summary.Assets++
+
+		if !e.options.DownloadAssets {
+			continue
+		}
+
+		for _, file := range asset.Fields.File {
+			if file.URL == "" {
+				continue
+			}
+
+			if err := e.download(file.URL); err != nil {
+				return fmt.Errorf("Export failed. Asset %v: %w", asset.ID, err)
+			}
+
+			e.summary.Files++
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("Export failed. Assets: %w", err)
+	}
+
+	return out.err
+}
+
+// AbsoluteURL returns the https URL of an asset file. Contentful returns
+// protocol relative URLs such as //images.ctfassets.net/...
+func AbsoluteURL(fileURL string) string {
+	if strings.HasPrefix(fileURL, "//") {
+		return "https:" + fileURL
+	}
+
+	return fileURL
+}
+
+// FilePath returns where ExportDir saves the asset file with the URL in dir
+func FilePath(dir string, fileURL string) (string, error) {
+	u, err := url.Parse(AbsoluteURL(fileURL))
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, u.Host, filepath.FromSlash(u.Path))
+	if u.Host == "" || !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid file URL %v", fileURL)
+	}
+
+	return path, nil
+}
+
+// download saves an asset file below the export directory
+func (e *export) download(fileURL string) error {
+	path, err := FilePath(e.dir, fileURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("GET", AbsoluteURL(fileURL), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.options.Doer.Do(req.WithContext(e.client.Context()))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download of %v failed with status %v", fileURL, resp.StatusCode)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
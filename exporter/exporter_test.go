@@ -0,0 +1,177 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+
+	"github.com/illyabusigin/contentful/contentfultest"
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+	"github.com/illyabusigin/contentful/transport"
+)
+
+const spaceID = "space"
+
+// newSpace creates a space with a content type, a published, a draft and an
+// archived entry and a published asset. The server is closed when the test
+// finishes.
+func newSpace(t *testing.T) *management.Client {
+	server := contentfultest.NewServer()
+	t.Cleanup(server.Close)
+	server.CreateSpace(spaceID, "Export")
+
+	client := server.ManagementClient(management.WithPageSize(2))
+
+	contentType, err := client.CreateContentType(&ContentType{
+		System: System{
+			ID:    "post",
+			Space: &Link{LinkData: &LinkData{Type: LinkType, LinkType: "Space", ID: spaceID}},
+		},
+		Name:         "Post",
+		DisplayField: "title",
+		Fields:       []Field{{ID: "title", Name: "Title", Type: ShortText}},
+	})
+	assert.Nil(t, err)
+
+	contentType, err = client.ActivateContentType(contentType)
+	assert.Nil(t, err)
+
+	for _, title := range []string{"Published", "Draft", "Archived"} {
+		entry, err := client.CreateEntry(&NewEntry{Fields: EntryFields{
+			"title": map[string]interface{}{"en-US": title},
+		}}, contentType)
+		assert.Nil(t, err)
+
+		switch title {
+		case "Published":
+			_, err = client.PublishEntry(entry)
+		case "Archived":
+			_, err = client.ArchiveEntry(entry)
+		}
+		assert.Nil(t, err)
+	}
+
+	data := []byte("cat")
+	upload, err := client.Upload(spaceID, bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+
+	asset, err := client.CreateAsset(&File{
+		SpaceID: spaceID,
+		Fields: FileFields{
+			Title:       map[string]string{"en-US": "Cat"},
+			Description: map[string]string{"en-US": "A cat"},
+			File:        map[string]FileData{"en-US": upload.FileData("cat.txt")},
+		},
+	})
+	assert.Nil(t, err)
+
+	_, err = client.ProcessAssetAndWait(asset, &management.ProcessAssetOptions{Publish: true})
+	assert.Nil(t, err)
+
+	return client
+}
+
+func TestExport(t *testing.T) {
+	client := newSpace(t)
+
+	buffer := &bytes.Buffer{}
+	summary, err := Export(client, spaceID, buffer, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &Summary{ContentTypes: 1, Locales: 1, Entries: 3, Assets: 1}, summary)
+
+	data := new(Data)
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), data))
+	assert.Len(t, data.ContentTypes, 1)
+	assert.True(t, data.ContentTypes[0].IsPublished())
+	assert.Equal(t, "en-US", data.Locales[0].Code)
+
+	titles := []string{}
+	for _, entry := range data.Entries {
+		title := entry.Fields["title"].(map[string]interface{})["en-US"].(string)
+		titles = append(titles, title)
+
+		assert.Equal(t, title == "Published", entry.IsPublished(), title)
+		assert.Equal(t, title == "Archived", entry.IsArchived(), title)
+	}
+	assert.Equal(t, []string{"Published", "Draft", "Archived"}, titles)
+
+	assert.True(t, data.Assets[0].IsPublished())
+	assert.Equal(t, "A cat", data.Assets[0].Fields.Description["en-US"])
+
+	// Filtered entries only
+	buffer.Reset()
+	summary, err = Export(client, spaceID, buffer, &Options{
+		EntryQuery:       NewQuery().Exists("sys.archivedAt", false),
+		SkipContentModel: true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &Summary{Entries: 2, Assets: 1}, summary)
+	assert.False(t, strings.Contains(buffer.String(), `"contentTypes"`))
+}
+
+func TestExportDir(t *testing.T) {
+	client := newSpace(t)
+
+	downloads := []string{}
+	doer := transport.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		downloads = append(downloads, req.URL.String())
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("cat")),
+		}, nil
+	})
+
+	dir := t.TempDir()
+	summary, err := ExportDir(client, spaceID, dir, &Options{DownloadAssets: true, Doer: doer})
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, FileName(spaceID)), summary.Path)
+	assert.Equal(t, 1, summary.Files)
+	assert.Len(t, downloads, 1)
+	assert.True(t, strings.HasPrefix(downloads[0], "https://"))
+
+	data, err := Load(summary.Path)
+	assert.Nil(t, err)
+	assert.Len(t, data.Entries, 3)
+	assert.Len(t, data.Assets, 1)
+
+	file := data.Assets[0].Fields.File["en-US"].URL
+	content, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(file, "//"))))
+	assert.Nil(t, err)
+	assert.Equal(t, "cat", string(content))
+}
+
+func TestExportEmptySpace(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+	server.CreateSpace(spaceID, "Empty")
+
+	client := management.New("token",
+		management.WithBaseURL(server.ManagementURL()),
+		management.WithRateLimit(0, 0))
+
+	path := filepath.Join(t.TempDir(), "export.json")
+	f, err := os.Create(path)
+	assert.Nil(t, err)
+
+	_, err = Export(client, spaceID, f, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	data, err := Load(path)
+	assert.Nil(t, err)
+	assert.Empty(t, data.ContentTypes)
+	assert.Empty(t, data.Entries)
+	assert.Len(t, data.Locales, 1)
+
+	_, err = Export(client, "", &bytes.Buffer{}, nil)
+	assert.NotNil(t, err)
+}
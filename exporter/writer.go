@@ -0,0 +1,82 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// writer streams the export document, writing every item as soon as it has
+// been fetched. The first error is kept and stops further writes.
+type writer struct {
+	w        *bufio.Writer
+	sections int
+	items    int
+	err      error
+}
+
+func newWriter(w io.Writer) *writer {
+	out := &writer{w: bufio.NewWriter(w)}
+	out.write("{")
+
+	return out
+}
+
+// begin starts an array property
+func (w *writer) begin(key string) {
+	if w.sections > 0 {
+		w.write(",")
+	}
+
+	name, _ := json.Marshal(key)
+	w.write("\n  " + string(name) + ": [")
+	w.sections++
+	w.items = 0
+}
+
+func (w *writer) item(v interface{}) {
+	if w.err != nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(v, "    ", "  ")
+	if err != nil {
+		w.err = err
+		return
+	}
+
+	if w.items > 0 {
+		w.write(",")
+	}
+
+	w.write("\n    " + string(data))
+	w.items++
+}
+
+// end closes the current array property
+func (w *writer) end() {
+	if w.items > 0 {
+		w.write("\n  ")
+	}
+
+	w.write("]")
+}
+
+// close finishes the document and flushes it
+func (w *writer) close() error {
+	w.write("\n}\n")
+
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+
+	return w.err
+}
+
+func (w *writer) write(s string) {
+	if w.err != nil {
+		return
+	}
+
+	_, w.err = w.w.WriteString(s)
+}
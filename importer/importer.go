@@ -0,0 +1,718 @@
+// Package importer recreates a space from an export written by the exporter
+// package or the contentful-export tool.
+//
+// Items are imported in dependency order: locales, content types, assets and
+// entries. Content types, assets and entries keep their IDs. Items are
+// published, activated or archived like in the export, entries are published
+// after the entries they link to.
+//
+// Items that already exist in the target space are skipped, unless their
+// sys.version or sys.publishedVersion is lower than in the export. Such items
+// are updated to the exported version. The outcome of every item is recorded in
+// a Report, which is saved after every item if Options.ReportPath is set.
+// Running the import again with the same report resumes it: items that were
+// imported or skipped at the same version are skipped and failed or unfinished
+// items are retried.
+//
+//	report, err := importer.ImportFile(client, spaceID, "backup/contentful-export-abc.json", &importer.Options{
+//		ReportPath: "backup/import-report.json",
+//		AssetDir:   "backup",
+//	})
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/illyabusigin/contentful/exporter"
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// Options configures an import
+type Options struct {
+	// ReportPath is where the report is saved after every item. An existing
+	// report is loaded to resume a previous import.
+	ReportPath string
+	// AssetDir is the directory an export with downloaded asset files was
+	// written to, see exporter.ExportDir. Files found there are uploaded
+	// through the Upload API instead of being fetched from their URL.
+	AssetDir string
+
+	// SkipContentModel skips locales and content types
+	SkipContentModel bool
+	// SkipContent skips assets and entries
+	SkipContent bool
+
+	// ProcessAssetOptions configures how long to wait for assets to be
+	// processed. Publish is ignored.
+	ProcessAssetOptions *management.ProcessAssetOptions
+}
+
+// ImportFile imports an export file into the space
+func ImportFile(client *management.Client, spaceID string, path string, options *Options) (*Report, error) {
+	data, err := exporter.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Import(client, spaceID, data, options)
+}
+
+// Import imports the exported data into the space. The report is returned
+// along with an error if any item failed.
+func Import(client *management.Client, spaceID string, data *exporter.Data, options *Options) (*Report, error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("Import failed. Space identifier is not valid!")
+	}
+
+	if data == nil {
+		return nil, fmt.Errorf("Import failed. Data cannot be nil!")
+	}
+
+	if options == nil {
+		options = &Options{}
+	}
+
+	report := NewReport()
+	if options.ReportPath != "" {
+		previous, err := LoadReport(options.ReportPath)
+		switch {
+		case err == nil:
+			report = previous
+		case !os.IsNotExist(err):
+			return nil, err
+		}
+	}
+
+	r := &run{
+		client:  client,
+		spaceID: spaceID,
+		data:    data,
+		options: options,
+		report:  report,
+		entries: map[string]*Entry{},
+	}
+
+	if err := r.run(); err != nil {
+		return report, err
+	}
+
+	if r.saveErr != nil {
+		return report, fmt.Errorf("Import failed. Report could not be saved: %w", r.saveErr)
+	}
+
+	if failed := report.Count(StatusFailed); failed > 0 {
+		return report, fmt.Errorf("Import failed. %v items could not be imported!", failed)
+	}
+
+	return report, nil
+}
+
+type run struct {
+	client  *management.Client
+	spaceID string
+	data    *exporter.Data
+	options *Options
+	report  *Report
+	saveErr error
+
+	// Items of the target space
+	locales      map[string]*Locale
+	contentTypes map[string]*ContentType
+	entries      map[string]*Entry
+}
+
+func (r *run) run() error {
+	if !r.options.SkipContentModel {
+		if err := r.importLocales(); err != nil {
+			return err
+		}
+
+		if err := r.importContentTypes(); err != nil {
+			return err
+		}
+	}
+
+	if !r.options.SkipContent {
+		if err := r.importAssets(); err != nil {
+			return err
+		}
+
+		if err := r.importEntries(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pending returns the record of the item if it still has to be imported
+func (r *run) pending(kind string, id string, version int) *Item {
+	item := r.report.item(kind, id)
+	if (item.Status == StatusSkipped || item.Status == StatusImported) && item.Version >= version {
+		return nil
+	}
+
+	item.Version = version
+
+	return item
+}
+
+// record stores the outcome of a step and saves the report
+func (r *run) record(item *Item, err error) {
+	item.Error = ""
+	if err != nil {
+		item.Status = StatusFailed
+		item.Error = err.Error()
+	}
+
+	r.save()
+}
+
+func (r *run) save() {
+	if r.options.ReportPath == "" || r.saveErr != nil {
+		return
+	}
+
+	r.saveErr = r.report.Save(r.options.ReportPath)
+}
+
+// behind reports whether an item of the target space is older than the
+// exported item and has to be updated
+func behind(target *System, exported *System) bool {
+	if target.Version < exported.Version {
+		return true
+	}
+
+	return exported.PublishedVersion > 0 && target.PublishedVersion < exported.PublishedVersion
+}
+
+func (r *run) spaceLink() *Link {
+	return &Link{LinkData: &LinkData{Type: LinkType, LinkType: "Space", ID: r.spaceID}}
+}
+
+/////////////
+// Locales //
+/////////////
+
+func (r *run) importLocales() error {
+	r.locales = map[string]*Locale{}
+
+	it := r.client.IterateLocales(r.spaceID)
+	for it.Next() {
+		r.locales[it.Locale().Code] = it.Locale()
+	}
+
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("Import failed. Locales: %w", err)
+	}
+
+	for _, locale := range orderLocales(r.data.Locales) {
+		if item := r.pending(KindLocale, locale.Code, locale.Version); item != nil {
+			r.record(item, r.importLocale(item, locale))
+		}
+	}
+
+	return nil
+}
+
+func (r *run) importLocale(item *Item, locale *Locale) error {
+	target := r.locales[locale.Code]
+	if target != nil && !item.Created && !behind(&target.System, &locale.System) {
+		item.Status = StatusSkipped
+		return nil
+	}
+
+	imported := &Locale{
+		System:                      System{Space: r.spaceLink()},
+		Name:                        locale.Name,
+		Code:                        locale.Code,
+		Optional:                    locale.Optional,
+		Fallback:                    locale.Fallback,
+		EnabledForContentManagement: locale.EnabledForContentManagement,
+		EnabledForContentDelivery:   locale.EnabledForContentDelivery,
+	}
+
+	var err error
+	if target == nil {
+		target, err = r.client.CreateLocale(r.spaceID, imported)
+		if err != nil {
+			return err
+		}
+
+		item.Created = true
+	} else {
+		imported.ID = target.ID
+		imported.Version = target.Version
+
+		if target, err = r.client.UpdateLocale(imported); err != nil {
+			return err
+		}
+	}
+
+	r.locales[locale.Code] = target
+	item.Status = StatusImported
+
+	return nil
+}
+
+// orderLocales sorts the locales so fallback locales are created first
+func orderLocales(locales []*Locale) []*Locale {
+	codes := map[string]*Locale{}
+	for _, locale := range locales {
+		codes[locale.Code] = locale
+	}
+
+	ordered := []*Locale{}
+	added := map[string]bool{}
+
+	var add func(locale *Locale)
+	add = func(locale *Locale) {
+		if added[locale.Code] {
+			return
+		}
+
+		added[locale.Code] = true
+		if fallback := codes[locale.Fallback]; fallback != nil {
+			add(fallback)
+		}
+
+		ordered = append(ordered, locale)
+	}
+
+	for _, locale := range locales {
+		add(locale)
+	}
+
+	return ordered
+}
+
+///////////////////
+// Content types //
+///////////////////
+
+func (r *run) loadContentTypes() error {
+	r.contentTypes = map[string]*ContentType{}
+
+	it := r.client.IterateContentTypes(r.spaceID, false)
+	for it.Next() {
+		contentType := it.ContentType()
+		if contentType.Space == nil {
+			contentType.Space = r.spaceLink()
+		}
+
+		r.contentTypes[contentType.ID] = contentType
+	}
+
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("Import failed. Content types: %w", err)
+	}
+
+	return nil
+}
+
+func (r *run) importContentTypes() error {
+	if err := r.loadContentTypes(); err != nil {
+		return err
+	}
+
+	for _, contentType := range r.data.ContentTypes {
+		if item := r.pending(KindContentType, contentType.ID, contentType.Version); item != nil {
+			r.record(item, r.importContentType(item, contentType))
+		}
+	}
+
+	return nil
+}
+
+func (r *run) importContentType(item *Item, contentType *ContentType) error {
+	target := r.contentTypes[contentType.ID]
+	if target != nil && !item.Created && !behind(&target.System, &contentType.System) {
+		item.Status = StatusSkipped
+		return nil
+	}
+
+	imported := *contentType
+	imported.System = System{ID: contentType.ID, Space: r.spaceLink()}
+
+	var err error
+	if target == nil {
+		target, err = r.client.CreateContentType(&imported)
+		if err != nil {
+			return err
+		}
+
+		item.Created = true
+	} else {
+		imported.Version = target.Version
+
+		if target, err = r.client.UpdateContentType(&imported); err != nil {
+			return err
+		}
+	}
+
+	r.contentTypes[contentType.ID] = target
+
+	if contentType.IsPublished() {
+		if target, err = r.client.ActivateContentType(target); err != nil {
+			return err
+		}
+
+		r.contentTypes[contentType.ID] = target
+	}
+
+	item.Status = StatusImported
+
+	return nil
+}
+
+////////////
+// Assets //
+////////////
+
+func (r *run) importAssets() error {
+	for _, asset := range r.data.Assets {
+		if item := r.pending(KindAsset, asset.ID, asset.Version); item != nil {
+			r.record(item, r.importAsset(item, asset))
+		}
+	}
+
+	return nil
+}
+
+func (r *run) importAsset(item *Item, asset *Asset) error {
+	// Assets are looked up first so files of existing assets aren't uploaded
+	target, err := r.client.FetchAsset(r.spaceID, asset.ID)
+	exists := err == nil
+	switch {
+	case exists && !item.Created && !behind(&target.System, &asset.System):
+		item.Status = StatusSkipped
+		return nil
+	case !exists && !errors.Is(err, ErrNotFound):
+		return err
+	}
+
+	files := map[string]AssetData{}
+	for locale, data := range asset.Fields.File {
+		if files[locale], err = r.assetData(data); err != nil {
+			return err
+		}
+	}
+
+	if !exists {
+		file := &File{
+			SpaceID: r.spaceID,
+			ID:      asset.ID,
+			Fields: FileFields{
+				Title:       asset.Fields.Title,
+				Description: asset.Fields.Description,
+				File:        map[string]FileData{},
+			},
+		}
+
+		for locale, data := range files {
+			file.Fields.File[locale] = FileData{
+				MIMEType:   data.MIMEType,
+				Name:       data.Name,
+				URL:        data.Upload,
+				UploadFrom: data.UploadFrom,
+			}
+		}
+
+		if target, err = r.client.CreateAsset(file); err != nil {
+			return err
+		}
+
+		item.Created = true
+	} else {
+		if target.IsArchived() {
+			if target, err = r.client.UnarchiveAsset(target); err != nil {
+				return err
+			}
+		}
+
+		target.Space = r.spaceLink()
+		target.Fields = AssetFields{
+			Title:       asset.Fields.Title,
+			Description: asset.Fields.Description,
+			File:        files,
+		}
+
+		if target, err = r.client.UpdateAsset(target); err != nil {
+			return err
+		}
+	}
+
+	item.Status = StatusCreated
+	r.save()
+
+	if target.Space == nil {
+		target.Space = r.spaceLink()
+	}
+
+	options := management.ProcessAssetOptions{}
+	if r.options.ProcessAssetOptions != nil {
+		options = *r.options.ProcessAssetOptions
+	}
+
+	options.Publish = asset.IsPublished() && !asset.IsArchived()
+
+	if target, err = r.client.ProcessAssetAndWait(target, &options); err != nil {
+		return err
+	}
+
+	if asset.IsArchived() {
+		if _, err = r.client.ArchiveAsset(target); err != nil {
+			return err
+		}
+	}
+
+	item.Status = StatusImported
+
+	return nil
+}
+
+// assetData returns the source of an exported file. Processed files are
+// fetched from their URL or uploaded from the asset directory.
+func (r *run) assetData(data AssetData) (AssetData, error) {
+	imported := AssetData{
+		MIMEType:   data.MIMEType,
+		Name:       data.Name,
+		Upload:     data.Upload,
+		UploadFrom: data.UploadFrom,
+	}
+
+	if data.URL == "" {
+		return imported, nil
+	}
+
+	imported.Upload = exporter.AbsoluteURL(data.URL)
+	imported.UploadFrom = nil
+
+	if r.options.AssetDir == "" {
+		return imported, nil
+	}
+
+	path, err := exporter.FilePath(r.options.AssetDir, data.URL)
+	if err != nil {
+		return imported, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return imported, nil
+	} else if err != nil {
+		return imported, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return imported, err
+	}
+
+	upload, err := r.client.Upload(r.spaceID, f, info.Size())
+	if err != nil {
+		return imported, err
+	}
+
+	imported.Upload = ""
+	imported.UploadFrom = upload.Link()
+
+	return imported, nil
+}
+
+/////////////
+// Entries //
+/////////////
+
+func (r *run) importEntries() error {
+	if r.contentTypes == nil {
+		if err := r.loadContentTypes(); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range r.data.Entries {
+		if item := r.pending(KindEntry, entry.ID, entry.Version); item != nil {
+			r.record(item, r.createEntry(item, entry))
+		}
+	}
+
+	// Entries are published once all of them exist, so links can be
+	// published first
+	created := []*Entry{}
+	for _, entry := range r.data.Entries {
+		if item := r.report.Item(KindEntry, entry.ID); item != nil && item.Status == StatusCreated {
+			created = append(created, entry)
+		}
+	}
+
+	for _, entry := range publishOrder(created) {
+		item := r.report.Item(KindEntry, entry.ID)
+		r.record(item, r.finishEntry(item, entry))
+	}
+
+	return nil
+}
+
+func (r *run) createEntry(item *Item, entry *Entry) error {
+	if entry.ContentType == nil || r.contentTypes[entry.ContentType.ID] == nil {
+		return fmt.Errorf("Import failed. Content type of entry %v does not exist!", entry.ID)
+	}
+
+	if !item.Created {
+		target, err := r.client.CreateEntry(&NewEntry{ID: entry.ID, Fields: entry.Fields}, r.contentTypes[entry.ContentType.ID])
+		switch {
+		case err == nil:
+			item.Created = true
+			item.Status = StatusCreated
+			r.entries[entry.ID] = target
+
+			return nil
+		case !errors.Is(err, ErrVersionMismatch):
+			return err
+		}
+	}
+
+	// The entry exists, it is updated if the import created it or it is older
+	// than the exported entry
+	target, err := r.client.FetchEntry(r.spaceID, entry.ID)
+	if err != nil {
+		return err
+	}
+
+	if !item.Created && !behind(&target.System, &entry.System) {
+		item.Status = StatusSkipped
+		return nil
+	}
+
+	if target.IsArchived() {
+		if target, err = r.client.UnarchiveEntry(r.withSpace(target)); err != nil {
+			return err
+		}
+	}
+
+	target.Fields = entry.Fields
+	if target, err = r.client.UpdateEntry(r.withSpace(target)); err != nil {
+		return err
+	}
+
+	item.Status = StatusCreated
+	r.entries[entry.ID] = target
+
+	return nil
+}
+
+// finishEntry publishes or archives the entry like in the export
+func (r *run) finishEntry(item *Item, entry *Entry) (err error) {
+	target := r.entries[entry.ID]
+	if target == nil {
+		if target, err = r.client.FetchEntry(r.spaceID, entry.ID); err != nil {
+			return err
+		}
+	}
+
+	target = r.withSpace(target)
+
+	switch {
+	case entry.IsArchived():
+		if target.IsPublished() {
+			if target, err = r.client.UnpublishEntry(target); err != nil {
+				return err
+			}
+		}
+
+		if !target.IsArchived() {
+			_, err = r.client.ArchiveEntry(r.withSpace(target))
+		}
+	case entry.IsPublished():
+		_, err = r.client.PublishEntry(target)
+	case target.IsPublished():
+		_, err = r.client.UnpublishEntry(target)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	item.Status = StatusImported
+	delete(r.entries, entry.ID)
+
+	return nil
+}
+
+func (r *run) withSpace(entry *Entry) *Entry {
+	if entry.Space == nil {
+		entry.Space = r.spaceLink()
+	}
+
+	return entry
+}
+
+// publishOrder sorts the entries so linked entries come before the entries
+// linking to them. Cycles are broken in the order of the export.
+func publishOrder(entries []*Entry) []*Entry {
+	ids := map[string]*Entry{}
+	for _, entry := range entries {
+		ids[entry.ID] = entry
+	}
+
+	ordered := []*Entry{}
+	visited := map[string]bool{}
+
+	var visit func(entry *Entry)
+	visit = func(entry *Entry) {
+		if visited[entry.ID] {
+			return
+		}
+
+		visited[entry.ID] = true
+		for _, id := range linkedEntries(map[string]interface{}(entry.Fields)) {
+			if linked := ids[id]; linked != nil {
+				visit(linked)
+			}
+		}
+
+		ordered = append(ordered, entry)
+	}
+
+	for _, entry := range entries {
+		visit(entry)
+	}
+
+	return ordered
+}
+
+// linkedEntries returns the IDs of the entries linked from a field value
+func linkedEntries(value interface{}) []string {
+	ids := []string{}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if sys, ok := v["sys"].(map[string]interface{}); ok && sys["type"] == LinkType && sys["linkType"] == "Entry" {
+			if id, ok := sys["id"].(string); ok {
+				return append(ids, id)
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			ids = append(ids, linkedEntries(v[key])...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			ids = append(ids, linkedEntries(item)...)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,316 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+
+	"github.com/illyabusigin/contentful/contentfultest"
+	"github.com/illyabusigin/contentful/exporter"
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+	"github.com/illyabusigin/contentful/transport"
+)
+
+func link(id string) map[string]interface{} {
+	return map[string]interface{}{"sys": map[string]interface{}{"type": LinkType, "linkType": "Entry", "id": id}}
+}
+
+// exportSource creates a space with a German locale, linked entries in
+// different states and a published asset, and exports it
+func exportSource(t *testing.T, server *contentfultest.Server) *exporter.Data {
+	server.CreateSpace("source", "Source")
+	client := server.ManagementClient()
+
+	_, err := client.CreateLocale("source", &Locale{Name: "German", Code: "de-DE", Fallback: "en-US", Optional: true})
+	assert.Nil(t, err)
+
+	contentType, err := client.CreateContentType(&ContentType{
+		System: System{
+			ID:    "post",
+			Space: &Link{LinkData: &LinkData{Type: LinkType, LinkType: "Space", ID: "source"}},
+		},
+		Name:         "Post",
+		DisplayField: "title",
+		Fields: []Field{
+			{ID: "title", Name: "Title", Type: ShortText, Localized: true},
+			{ID: "related", Name: "Related", Type: LinkType, LinkType: "Entry"},
+		},
+	})
+	assert.Nil(t, err)
+
+	contentType, err = client.ActivateContentType(contentType)
+	assert.Nil(t, err)
+
+	// "first" links to "second", which has to be published first
+	entries := []struct {
+		id      string
+		related string
+		status  string
+	}{
+		{"first", "second", "published"},
+		{"second", "", "published"},
+		{"draft", "first", "draft"},
+		{"old", "", "archived"},
+	}
+
+	for _, e := range entries {
+		fields := EntryFields{"title": map[string]interface{}{"en-US": e.id, "de-DE": e.id + " (de)"}}
+		if e.related != "" {
+			fields["related"] = map[string]interface{}{"en-US": link(e.related)}
+		}
+
+		entry, err := client.CreateEntry(&NewEntry{ID: e.id, Fields: fields}, contentType)
+		assert.Nil(t, err)
+		assert.Equal(t, e.id, entry.ID)
+	}
+
+	for _, e := range entries {
+		entry, err := client.FetchEntry("source", e.id)
+		assert.Nil(t, err)
+
+		switch e.status {
+		case "published":
+			_, err = client.PublishEntry(entry)
+		case "archived":
+			_, err = client.ArchiveEntry(entry)
+		}
+		assert.Nil(t, err)
+	}
+
+	data := []byte("cat")
+	upload, err := client.Upload("source", bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+
+	asset, err := client.CreateAsset(&File{
+		SpaceID: "source",
+		ID:      "cat",
+		Fields: FileFields{
+			Title: map[string]string{"en-US": "Cat"},
+			File:  map[string]FileData{"en-US": upload.FileData("cat.txt")},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "cat", asset.ID)
+
+	_, err = client.ProcessAssetAndWait(asset, &management.ProcessAssetOptions{Publish: true})
+	assert.Nil(t, err)
+
+	buffer := &bytes.Buffer{}
+	_, err = exporter.Export(client, "source", buffer, nil)
+	assert.Nil(t, err)
+
+	path := filepath.Join(t.TempDir(), "export.json")
+	assert.Nil(t, ioutil.WriteFile(path, buffer.Bytes(), 0644))
+
+	exported, err := exporter.Load(path)
+	assert.Nil(t, err)
+
+	return exported
+}
+
+func TestImport(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+
+	data := exportSource(t, server)
+	server.CreateSpace("target", "Target")
+
+	// Publishing is recorded to check the order
+	writes := &contentfultest.Writes{}
+	client := server.ManagementClient(management.WithMiddleware(writes.Middleware))
+
+	report, err := Import(client, "target", data, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, report.Count(StatusImported), report.String())
+	assert.Equal(t, 1, report.Count(StatusSkipped), "The default locale exists")
+	published := []string{}
+	for _, write := range writes.All() {
+		if write.Method == http.MethodPut && strings.HasPrefix(write.Path, "entries/") && strings.HasSuffix(write.Path, "/published") {
+			published = append(published, strings.Split(write.Path, "/")[1])
+		}
+	}
+	assert.Equal(t, []string{"second", "first"}, published)
+
+	locale := report.Item(KindLocale, "de-DE")
+	assert.True(t, locale.Created)
+
+	contentType, err := client.FetchContentType("target", "post")
+	assert.Nil(t, err)
+	assert.True(t, contentType.IsPublished())
+
+	first, err := client.FetchEntry("target", "first")
+	assert.Nil(t, err)
+	assert.True(t, first.IsPublished())
+	assert.Equal(t, "first (de)", first.Fields["title"].(map[string]interface{})["de-DE"])
+
+	draft, err := client.FetchEntry("target", "draft")
+	assert.Nil(t, err)
+	assert.False(t, draft.IsPublished())
+
+	old, err := client.FetchEntry("target", "old")
+	assert.Nil(t, err)
+	assert.True(t, old.IsArchived())
+
+	asset, err := client.FetchAsset("target", "cat")
+	assert.Nil(t, err)
+	assert.True(t, asset.IsPublished())
+	assert.Contains(t, asset.Fields.File["en-US"].URL, "/target/cat/")
+
+	// Importing again skips the existing items
+	report, err = Import(client, "target", data, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 8, report.Count(StatusSkipped))
+}
+
+func TestImportResume(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+
+	data := exportSource(t, server)
+	server.CreateSpace("target", "Target")
+
+	// Publishing "second" fails once
+	failures := 1
+	requests := 0
+	client := server.ManagementClient(management.WithMiddleware(func(next transport.Doer) transport.Doer {
+		return transport.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			if failures > 0 && strings.HasSuffix(req.URL.Path, "/entries/second/published") {
+				failures--
+				return nil, errors.New("connection reset")
+			}
+
+			return next.Do(req)
+		})
+	}))
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	report, err := Import(client, "target", data, &Options{ReportPath: path})
+	assert.NotNil(t, err)
+	assert.Len(t, report.Failed(), 1)
+	assert.Equal(t, "second", report.Failed()[0].ID)
+	assert.True(t, report.Failed()[0].Created)
+
+	saved, err := LoadReport(path)
+	assert.Nil(t, err)
+	assert.Equal(t, report.Items, saved.Items)
+
+	// The import resumes with the failed entry
+	requests = 0
+	report, err = Import(client, "target", data, &Options{ReportPath: path})
+	assert.Nil(t, err, report.String())
+	assert.Empty(t, report.Failed())
+	assert.Equal(t, 5, requests, "List locales and content types, fetch, update and publish the entry")
+
+	second, err := client.FetchEntry("target", "second")
+	assert.Nil(t, err)
+	assert.True(t, second.IsPublished())
+
+	// A newer export updates the imported entries. The order of the exported
+	// entries depends on their creation time, so the draft is looked up by ID.
+	for _, entry := range data.Entries {
+		if entry.ID == "draft" {
+			entry.Version++
+			entry.Fields["title"] = map[string]interface{}{"en-US": "updated"}
+		}
+	}
+
+	report, err = Import(client, "target", data, &Options{ReportPath: path, SkipContentModel: true})
+	assert.Nil(t, err)
+
+	draft, err := client.FetchEntry("target", "draft")
+	assert.Nil(t, err)
+	assert.Equal(t, "updated", draft.Fields["title"].(map[string]interface{})["en-US"])
+	assert.False(t, draft.IsPublished())
+}
+
+func TestImportOutdated(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+
+	data := exportSource(t, server)
+	server.CreateSpace("target", "Target")
+	client := server.ManagementClient()
+
+	_, err := Import(client, "target", data, &Options{SkipContent: true})
+	assert.Nil(t, err)
+
+	contentType, err := client.FetchContentType("target", "post")
+	assert.Nil(t, err)
+
+	// "first" is older than the published export, "draft" has the same version
+	for _, id := range []string{"first", "draft"} {
+		_, err = client.CreateEntry(&NewEntry{ID: id, Fields: EntryFields{
+			"title": map[string]interface{}{"en-US": "stale " + id},
+		}}, contentType)
+		assert.Nil(t, err)
+	}
+
+	report, err := Import(client, "target", data, &Options{SkipContentModel: true})
+	assert.Nil(t, err, report.String())
+
+	item := report.Item(KindEntry, "first")
+	assert.Equal(t, StatusImported, item.Status)
+	assert.False(t, item.Created)
+
+	first, err := client.FetchEntry("target", "first")
+	assert.Nil(t, err)
+	assert.True(t, first.IsPublished())
+	assert.Equal(t, "first", first.Fields["title"].(map[string]interface{})["en-US"])
+
+	assert.Equal(t, StatusSkipped, report.Item(KindEntry, "draft").Status)
+
+	draft, err := client.FetchEntry("target", "draft")
+	assert.Nil(t, err)
+	assert.Equal(t, "stale draft", draft.Fields["title"].(map[string]interface{})["en-US"])
+}
+
+func TestImportAssetDir(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+
+	data := exportSource(t, server)
+	server.CreateSpace("target", "Target")
+
+	dir := t.TempDir()
+	path, err := exporter.FilePath(dir, data.Assets[0].Fields.File["en-US"].URL)
+	assert.Nil(t, err)
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.Nil(t, ioutil.WriteFile(path, []byte("local cat"), 0644))
+
+	client := server.ManagementClient()
+	_, err = Import(client, "target", data, &Options{AssetDir: dir})
+	assert.Nil(t, err)
+
+	asset, err := client.FetchAsset("target", "cat")
+	assert.Nil(t, err)
+	assert.Equal(t, len("local cat"), asset.Fields.File["en-US"].Detail.Size)
+}
+
+func TestPublishOrder(t *testing.T) {
+	entry := func(id string, links ...string) *Entry {
+		related := []interface{}{}
+		for _, l := range links {
+			related = append(related, link(l))
+		}
+
+		return &Entry{System: System{ID: id}, Fields: EntryFields{"related": map[string]interface{}{"en-US": related}}}
+	}
+
+	// c and d link to each other
+	entries := []*Entry{entry("a", "b", "c"), entry("b"), entry("c", "d"), entry("d", "c", "missing")}
+
+	ids := []string{}
+	for _, e := range publishOrder(entries) {
+		ids = append(ids, e.ID)
+	}
+	assert.Equal(t, []string{"b", "d", "c", "a"}, ids)
+}
@@ -0,0 +1,155 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Status is the outcome of importing an item
+type Status string
+
+// Import statuses
+const (
+	// StatusCreated items exist in the target space but still have to be
+	// published, processed or archived
+	StatusCreated Status = "created"
+	// StatusImported items are in the same state as in the export
+	StatusImported Status = "imported"
+	// StatusSkipped items already existed in the target space and were left
+	// untouched
+	StatusSkipped Status = "skipped"
+	// StatusFailed items could not be imported, see Item.Error
+	StatusFailed Status = "failed"
+)
+
+// Item kinds
+const (
+	KindLocale      = "Locale"
+	KindContentType = "ContentType"
+	KindAsset       = "Asset"
+	KindEntry       = "Entry"
+)
+
+// Item records the import of an exported item
+type Item struct {
+	Kind string `json:"kind"`
+	// ID is the identifier of the item, or the code of a locale
+	ID string `json:"id"`
+	// Version is the version of the item in the export
+	Version int    `json:"version"`
+	Status  Status `json:"status"`
+	Error   string `json:"error,omitempty"`
+
+	// Created is set once the import created the item in the target space.
+	// Such items are updated when they are imported again.
+	Created bool `json:"created,omitempty"`
+}
+
+// Report records the outcome of an import. Passing the report of an
+// interrupted import to the next one resumes it, see Options.ReportPath.
+type Report struct {
+	Items []*Item `json:"items"`
+
+	index map[string]*Item
+}
+
+// NewReport returns an empty report
+func NewReport() *Report {
+	return &Report{Items: []*Item{}, index: map[string]*Item{}}
+}
+
+// LoadReport reads a report written by Save
+func LoadReport(path string) (*Report, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := NewReport()
+	if err = json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("LoadReport failed. %v: %v", path, err)
+	}
+
+	for _, item := range report.Items {
+		report.index[item.Kind+":"+item.ID] = item
+	}
+
+	return report, nil
+}
+
+// Save writes the report to a file. The file is replaced atomically so an
+// interrupted import never leaves a truncated report behind.
+func (r *Report) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(append(data, '\n'))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Item returns the record of an item, or nil if the item hasn't been imported
+func (r *Report) Item(kind string, id string) *Item {
+	return r.index[kind+":"+id]
+}
+
+// Count returns the number of items with the status
+func (r *Report) Count(status Status) int {
+	count := 0
+	for _, item := range r.Items {
+		if item.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Failed returns the items that could not be imported
+func (r *Report) Failed() []*Item {
+	failed := []*Item{}
+	for _, item := range r.Items {
+		if item.Status == StatusFailed {
+			failed = append(failed, item)
+		}
+	}
+
+	return failed
+}
+
+// String summarizes the report
+func (r *Report) String() string {
+	return fmt.Sprintf("%v imported, %v skipped, %v pending, %v failed",
+		r.Count(StatusImported), r.Count(StatusSkipped), r.Count(StatusCreated), r.Count(StatusFailed))
+}
+
+// item returns the record of an item, adding it if necessary
+func (r *Report) item(kind string, id string) *Item {
+	if item := r.Item(kind, id); item != nil {
+		return item
+	}
+
+	item := &Item{Kind: kind, ID: id}
+	r.Items = append(r.Items, item)
+	r.index[kind+":"+id] = item
+
+	return item
+}
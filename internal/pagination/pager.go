@@ -0,0 +1,66 @@
+// Package pagination keeps track of the pagination state of the iterators of
+// the delivery and management clients
+package pagination
+
+import (
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// Pager keeps track of the pagination state of an iterator
+type Pager struct {
+	// PageSize is the number of items requested per page
+	PageSize int
+
+	offset  int
+	total   int
+	index   int
+	length  int
+	started bool
+	err     error
+}
+
+// Next advances to the next item, loading the following page with fetch once
+// the current page is exhausted. fetch must return the number of items in the
+// loaded page.
+func (p *Pager) Next(fetch func(limit int, offset int) (count int, pagination *Pagination, err error)) bool {
+	if p.err != nil {
+		return false
+	}
+
+	p.index++
+	if p.index < p.length {
+		return true
+	}
+
+	if p.started && p.offset >= p.total {
+		return false
+	}
+
+	count, pagination, err := fetch(p.PageSize, p.offset)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.started = true
+	p.index = 0
+	p.length = count
+	p.offset += count
+	p.total = p.offset
+
+	if pagination != nil && count > 0 {
+		p.total = pagination.Total
+	}
+
+	return count > 0
+}
+
+// Index returns the index of the current item in the loaded page
+func (p *Pager) Index() int {
+	return p.index
+}
+
+// Err returns the error that stopped the pagination, if any
+func (p *Pager) Err() error {
+	return p.err
+}
@@ -19,8 +19,6 @@ func (c *Client) CreateContentDeliveryAPIKey(spaceID string, name string) (key *
 		return
 	}
 
-	c.rl.Wait()
-
 	type apikey struct {
 		Name string `json:"name"`
 	}
@@ -37,7 +35,7 @@ func (c *Client) CreateContentDeliveryAPIKey(spaceID string, name string) (key *
 		return
 	}
 
-	_, err = c.sling.Do(req, key, contentfulError)
+	_, err = c.do(req, key, contentfulError)
 
 	return key, handleError(err, contentfulError)
 }
@@ -56,8 +54,6 @@ func (c *Client) FetchContentDeliveryAPIKeys(spaceID string, limit int, offset i
 		limit = 100
 	}
 
-	c.rl.Wait()
-
 	type keysResponse struct {
 		*Pagination
 		Items []*APIKey `json:"items"`
@@ -83,7 +79,7 @@ func (c *Client) FetchContentDeliveryAPIKeys(spaceID string, limit int, offset i
 	req.URL.RawQuery = q.Encode()
 
 	// Perform request
-	_, err = c.sling.Do(req, results, contentfulError)
+	_, err = c.do(req, results, contentfulError)
 
 	return results.Items, results.Pagination, handleError(err, contentfulError)
 }
@@ -0,0 +1,130 @@
+package management
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// ProcessAssetOptions configures ProcessAssetAndWait
+type ProcessAssetOptions struct {
+	// Timeout is how long to wait for processing to finish. Defaults to two
+	// minutes.
+	Timeout time.Duration
+	// PollInterval is the delay before the asset is first fetched. It doubles
+	// for every subsequent poll up to MaxPollInterval. Defaults to 500ms and
+	// 10s.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+
+	// Publish publishes the asset once all files are processed
+	Publish bool
+}
+
+func (o *ProcessAssetOptions) withDefaults() *ProcessAssetOptions {
+	options := ProcessAssetOptions{}
+	if o != nil {
+		options = *o
+	}
+
+	if options.Timeout <= 0 {
+		options.Timeout = 2 * time.Minute
+	}
+
+	if options.PollInterval <= 0 {
+		options.PollInterval = 500 * time.Millisecond
+	}
+
+	if options.MaxPollInterval < options.PollInterval {
+		options.MaxPollInterval = 10 * time.Second
+	}
+
+	return &options
+}
+
+// ProcessAssetAndWait processes the files of every locale of the asset that
+// have not been processed yet and polls the asset until each of them has a
+// URL. The returned asset has the latest version and can be passed to
+// PublishAsset, or is already published if options.Publish is set. A nil
+// options uses the defaults.
+//
+// Waiting is aborted when the timeout elapses or the client's context is done.
+func (c *Client) ProcessAssetAndWait(asset *Asset, options *ProcessAssetOptions) (processed *Asset, err error) {
+	if asset == nil {
+		return nil, fmt.Errorf("ProcessAssetAndWait failed. Asset cannot be nil!")
+	}
+
+	if err = asset.Validate(); err != nil {
+		return
+	}
+
+	options = options.withDefaults()
+	pending := unprocessedLocales(asset)
+
+	for _, locale := range pending {
+		if err = c.ProcessAsset(asset, locale); err != nil {
+			return nil, err
+		}
+	}
+
+	processed = asset
+	deadline := time.Now().Add(options.Timeout)
+	interval := options.PollInterval
+
+	for len(pending) > 0 {
+		// The last poll happens at the deadline
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil, fmt.Errorf("ProcessAssetAndWait failed. Asset %v was not processed within %v, pending locales: %v", asset.ID, options.Timeout, pending)
+		}
+
+		if interval < wait {
+			wait = interval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-c.Context().Done():
+			timer.Stop()
+			return nil, c.Context().Err()
+		case <-timer.C:
+		}
+
+		if processed, err = c.FetchAsset(asset.Space.ID, asset.ID); err != nil {
+			return nil, err
+		}
+
+		pending = unprocessedLocales(processed)
+
+		interval *= 2
+		if interval > options.MaxPollInterval {
+			interval = options.MaxPollInterval
+		}
+	}
+
+	if options.Publish {
+		if processed.Space == nil {
+			processed.Space = asset.Space
+		}
+
+		return c.PublishAsset(processed)
+	}
+
+	return processed, nil
+}
+
+// unprocessedLocales returns the locales whose file has no URL yet
+func unprocessedLocales(asset *Asset) []string {
+	locales := []string{}
+	for locale, data := range asset.Fields.File {
+		if data.URL == "" {
+			locales = append(locales, locale)
+		}
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
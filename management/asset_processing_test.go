@@ -0,0 +1,97 @@
+package management
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/illyabusigin/contentful/models"
+	assert "github.com/stretchr/testify/require"
+)
+
+func unprocessedAsset() *Asset {
+	asset := &Asset{
+		Fields: AssetFields{
+			Title: map[string]string{"en-US": "Logo", "de-DE": "Logo"},
+			File: map[string]AssetData{
+				"en-US": {Name: "logo.png", MIMEType: "image/png", Upload: "https://example.com/logo.png"},
+				"de-DE": {Name: "logo.png", MIMEType: "image/png", URL: "//images.ctfassets.net/logo.png"},
+			},
+		},
+	}
+	asset.ID = "asset123"
+	asset.Version = 1
+	asset.Space = &Link{LinkData: &LinkData{Type: LinkType, LinkType: "Space", ID: "space123"}}
+
+	return asset
+}
+
+func TestProcessAssetAndWait(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	doer := &sequenceDoer{bodies: []string{
+		`{}`,
+		`{"sys": {"id": "asset123", "version": 2, "space": {"sys": {"id": "space123"}}}, "fields": {"file": {"en-US": {"upload": "https://example.com/logo.png"}}}}`,
+		`{"sys": {"id": "asset123", "version": 3, "space": {"sys": {"id": "space123"}}}, "fields": {"file": {"en-US": {"url": "//images.ctfassets.net/logo.png"}}}}`,
+		`{"sys": {"id": "asset123", "version": 4, "publishedVersion": 3}}`,
+	}}
+	client.sling = client.sling.New().Doer(doer)
+
+	asset, err := client.ProcessAssetAndWait(unprocessedAsset(), &ProcessAssetOptions{
+		PollInterval: time.Millisecond,
+		Publish:      true,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4, asset.Version)
+	assert.Len(t, doer.requests, 4)
+
+	assert.Equal(t, http.MethodPut, doer.requests[0].Method)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/assets/asset123/files/en-US/process", doer.requests[0].URL.String(), "Only unprocessed locales should be processed")
+	assert.Equal(t, http.MethodGet, doer.requests[1].Method)
+	assert.Equal(t, http.MethodGet, doer.requests[2].Method)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/assets/asset123/published", doer.requests[3].URL.String())
+	assert.Equal(t, "3", doer.requests[3].Header.Get("X-Contentful-Version"), "Latest version should be published")
+}
+
+func TestProcessAssetAndWaitTimeout(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	unprocessed := `{"sys": {"id": "asset123", "version": 2, "space": {"sys": {"id": "space123"}}}, "fields": {"file": {"en-US": {"upload": "https://example.com/logo.png"}}}}`
+	doer := &sequenceDoer{bodies: []string{`{}`, unprocessed, unprocessed}}
+	client.sling = client.sling.New().Doer(doer)
+
+	_, err := client.ProcessAssetAndWait(unprocessedAsset(), &ProcessAssetOptions{
+		Timeout:         15 * time.Millisecond,
+		PollInterval:    10 * time.Millisecond,
+		MaxPollInterval: 10 * time.Millisecond,
+	})
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "en-US")
+	assert.Len(t, doer.requests, 3, "The asset should be polled once more at the deadline")
+
+	// A timeout shorter than the poll interval still polls at the deadline
+	doer = &sequenceDoer{bodies: []string{
+		`{}`,
+		`{"sys": {"id": "asset123", "version": 3, "space": {"sys": {"id": "space123"}}}, "fields": {"file": {"en-US": {"url": "//images.ctfassets.net/logo.png"}}}}`,
+	}}
+	client.sling = client.sling.New().Doer(doer)
+
+	asset, err := client.ProcessAssetAndWait(unprocessedAsset(), &ProcessAssetOptions{
+		Timeout:      5 * time.Millisecond,
+		PollInterval: time.Minute,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, asset.Version)
+
+	// Cancelled context
+	doer.bodies = []string{`{}`}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.WithContext(ctx).ProcessAssetAndWait(unprocessedAsset(), nil)
+	assert.Equal(t, context.Canceled, err)
+}
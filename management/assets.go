@@ -6,7 +6,8 @@ import (
 	. "github.com/illyabusigin/contentful/models"
 )
 
-// CreateAsset creates a new asset. It's important to note that the asset still
+// CreateAsset creates a new asset with the ID of the file, or an ID generated
+// by Contentful if it is empty. It's important to note that the asset still
 // needs to be processed and published to be availably through the delivery API.
 func (c *Client) CreateAsset(file *File) (created *Asset, err error) {
 	if file == nil {
@@ -18,16 +19,27 @@ func (c *Client) CreateAsset(file *File) (created *Asset, err error) {
 		return
 	}
 
-	c.rl.Wait()
 	created = &Asset{}
 
 	created = new(Asset)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/assets", file.SpaceID)
-	_, err = c.sling.New().
-		Post(path).
+	builder := c.sling.New().Post(c.spacePath(file.SpaceID, "assets"))
+
+	// Assets with an ID are created with a PUT, which fails with
+	// ErrVersionMismatch if the asset already exists
+	if file.ID != "" {
+		builder = c.sling.New().Put(c.spacePath(file.SpaceID, "assets/%v", file.ID))
+	}
+
+	req, err := builder.
 		BodyJSON(file).
-		Receive(created, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, created, contentfulError)
 
 	return created, handleError(err, contentfulError)
 }
@@ -43,31 +55,39 @@ func (c *Client) UpdateAsset(asset *Asset) (updated *Asset, err error) {
 		return
 	}
 
-	c.rl.Wait()
-
 	updated = new(Asset)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/assets/%v", asset.System.Space.ID, asset.System.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(asset.System.Space.ID, "assets/%v", asset.System.ID)
+	req, err := c.sling.New().
 		Put(path).
 		Set("X-Contentful-Version", fmt.Sprintf("%v", asset.System.Version)).
 		BodyJSON(asset).
-		Receive(updated, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, updated, contentfulError)
 
 	return updated, handleError(err, contentfulError)
 }
 
 // FetchAsset will return the specified asset.
 func (c *Client) FetchAsset(spaceID string, assetID string) (asset *Asset, err error) {
-	c.rl.Wait()
-
 	asset = new(Asset)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/assets/%v", spaceID, assetID)
-	_, err = c.sling.New().
+	path := c.spacePath(spaceID, "assets/%v", assetID)
+	req, err := c.sling.New().
 		Get(path).
 		BodyJSON(asset).
-		Receive(asset, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, asset, contentfulError)
 
 	return asset, handleError(err, contentfulError)
 }
@@ -75,20 +95,28 @@ func (c *Client) FetchAsset(spaceID string, assetID string) (asset *Asset, err e
 // QueryAssets will return all assets associated with a space. You can toggle
 // the published flag to only fetch published assets.
 func (c *Client) QueryAssets(spaceID string, published bool, params map[string]string, limit int, offset int) (assets []*Asset, pagination *Pagination, err error) {
+	return c.SearchAssets(spaceID, published, NewQueryFromParams(params), limit, offset)
+}
+
+// SearchAssets will return all assets associated with a space matching the
+// query. You can toggle the published flag to only fetch published assets.
+func (c *Client) SearchAssets(spaceID string, published bool, query *Query, limit int, offset int) (assets []*Asset, pagination *Pagination, err error) {
 	if spaceID == "" {
 		return nil, nil, fmt.Errorf("FetchAssets failed. Space identifier is not valid!")
 	}
 
+	if query == nil {
+		query = NewQuery()
+	}
+
 	if limit <= 0 {
 		return nil, nil, fmt.Errorf("FetchAssets failed. Limit must be greater than 0")
 	}
 
-	if limit > PaginationSizeLimit {
-		limit = PaginationSizeLimit
+	if limit > c.pageSize {
+		limit = c.pageSize
 	}
 
-	c.rl.Wait()
-
 	type assetsResponse struct {
 		*Pagination
 		Items []*Asset `json:"items"`
@@ -98,10 +126,10 @@ func (c *Client) QueryAssets(spaceID string, published bool, params map[string]s
 	contentfulError := new(Error)
 	path := func() string {
 		if published {
-			return fmt.Sprintf("spaces/%v/public/assets", spaceID)
+			return c.spacePath(spaceID, "public/assets")
 		}
 
-		return fmt.Sprintf("spaces/%v/assets", spaceID)
+		return c.spacePath(spaceID, "assets")
 	}
 
 	req, err := c.sling.New().
@@ -114,15 +142,15 @@ func (c *Client) QueryAssets(spaceID string, published bool, params map[string]s
 
 	// Add query parameters
 	q := req.URL.Query()
-	for k, v := range params {
-		q.Set(k, v)
+	for k, v := range query.Values() {
+		q[k] = v
 	}
 
 	q.Set("skip", fmt.Sprintf("%v", offset))
 	q.Set("limit", fmt.Sprintf("%v", limit))
 	req.URL.RawQuery = q.Encode()
 
-	_, err = c.sling.Do(req, results, contentfulError)
+	_, err = c.do(req, results, contentfulError)
 
 	return results.Items, results.Pagination, handleError(err, contentfulError)
 }
@@ -139,13 +167,17 @@ func (c *Client) ProcessAsset(asset *Asset, localeCode string) (err error) {
 		return fmt.Errorf("ProcessAsset failed. Locale cannot be empty!")
 	}
 
-	c.rl.Wait()
-
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/assets/%v/files/%v/process", asset.Space.ID, asset.ID, localeCode)
-	_, err = c.sling.New().
+	path := c.spacePath(asset.Space.ID, "assets/%v/files/%v/process", asset.ID, localeCode)
+	req, err := c.sling.New().
 		Put(path).
-		Receive(nil, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, nil, contentfulError)
 
 	return handleError(err, contentfulError)
 }
@@ -156,15 +188,19 @@ func (c *Client) PublishAsset(asset *Asset) (published *Asset, err error) {
 		return nil, fmt.Errorf("PublishAsset failed. Asset cannot be nil!")
 	}
 
-	c.rl.Wait()
-
 	published = new(Asset)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/assets/%v/published", asset.Space.ID, asset.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(asset.Space.ID, "assets/%v/published", asset.ID)
+	req, err := c.sling.New().
 		Put(path).
 		Set("X-Contentful-Version", fmt.Sprintf("%v", asset.System.Version)).
-		Receive(published, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, published, contentfulError)
 
 	return published, handleError(err, contentfulError)
 }
@@ -175,14 +211,18 @@ func (c *Client) UnpublishAsset(asset *Asset) (unpublished *Asset, err error) {
 		return nil, fmt.Errorf("UnpublishAsset failed. Asset cannot be nil!")
 	}
 
-	c.rl.Wait()
-
 	unpublished = new(Asset)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/assets/%v/published", asset.Space.ID, asset.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(asset.Space.ID, "assets/%v/published", asset.ID)
+	req, err := c.sling.New().
 		Delete(path).
-		Receive(unpublished, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, unpublished, contentfulError)
 
 	return unpublished, handleError(err, contentfulError)
 }
@@ -193,13 +233,17 @@ func (c *Client) DeleteAsset(asset *Asset) (err error) {
 		return fmt.Errorf("DeleteAsset failed. Asset cannot be nil!")
 	}
 
-	c.rl.Wait()
-
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/assets/%v", asset.Space.ID, asset.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(asset.Space.ID, "assets/%v", asset.ID)
+	req, err := c.sling.New().
 		Delete(path).
-		Receive(nil, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, nil, contentfulError)
 
 	return handleError(err, contentfulError)
 }
@@ -216,14 +260,18 @@ func (c *Client) ArchiveAsset(asset *Asset) (archived *Asset, err error) {
 		return
 	}
 
-	c.rl.Wait()
-
 	archived = new(Asset)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/assets/%v/archived", asset.Space.ID, asset.System.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(asset.Space.ID, "assets/%v/archived", asset.System.ID)
+	req, err := c.sling.New().
 		Put(path).
-		Receive(archived, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, archived, contentfulError)
 
 	return archived, handleError(err, contentfulError)
 }
@@ -239,14 +287,18 @@ func (c *Client) UnarchiveAsset(asset *Asset) (unarchived *Asset, err error) {
 		return
 	}
 
-	c.rl.Wait()
-
 	unarchived = new(Asset)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/assets/%v/archived", asset.Space.ID, asset.System.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(asset.Space.ID, "assets/%v/archived", asset.System.ID)
+	req, err := c.sling.New().
 		Delete(path).
-		Receive(unarchived, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, unarchived, contentfulError)
 
 	return unarchived, handleError(err, contentfulError)
 }
@@ -1,6 +1,7 @@
 package management
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,9 +10,13 @@ import (
 	"github.com/ingaged/sling"
 
 	"github.com/illyabusigin/contentful/models"
+	"github.com/illyabusigin/contentful/transport"
 )
 
-const baseURL = "https://api.contentful.com"
+const (
+	baseURL       = "https://api.contentful.com"
+	uploadBaseURL = "https://upload.contentful.com"
+)
 
 // PaginationSizeLimit is the sizel limit for pages
 var PaginationSizeLimit = 1000
@@ -20,8 +25,15 @@ var PaginationSizeLimit = 1000
 type Client struct {
 	AccessToken string
 
-	sling *sling.Sling
-	rl    *rate.RateLimiter
+	sling       *sling.Sling
+	uploadURL   string
+	doer        Doer
+	middleware  []transport.Middleware
+	rl          *rate.RateLimiter
+	ctx         context.Context
+	logger      transport.StructuredLogger
+	environment string
+	pageSize    int
 }
 
 ////////////////////
@@ -30,18 +42,162 @@ type Client struct {
 
 // NewClient creates a new Contentful API client
 func NewClient(accessToken string, version string, httpClient *http.Client) *Client {
+	return New(accessToken, WithVersion(version), WithHTTPClient(httpClient))
+}
+
+// New creates a new Contentful API client configured by the provided options.
+// Clients don't share any configuration, so differently configured clients can
+// be used side by side.
+func New(accessToken string, opts ...Option) *Client {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	client := &Client{
 		AccessToken: accessToken,
-		sling: sling.New().Client(httpClient).Base(baseURL).
-			Set("Content-Type", contentTypeHeader(version)).
+		uploadURL:   o.uploadURL,
+		doer:        o.doer,
+		middleware:  o.middleware,
+		logger:      o.logger,
+		environment: o.environment,
+		pageSize:    o.pageSize,
+		sling: sling.New().Base(o.baseURL).
+			Set("Content-Type", contentTypeHeader(o.version)).
 			Set("Authorization", authorizationHeader(accessToken)),
 	}
 
-	client.rl = rate.New(10, time.Second*1)
+	if o.userAgent != "" {
+		client.sling.Set("User-Agent", o.userAgent)
+	}
+
+	if o.rateLimit > 0 {
+		client.rl = rate.New(o.rateLimit, o.rateInterval)
+	}
+
+	client.SetRetryPolicy(o.retryPolicy)
 
 	return client
 }
 
+// SetRetryPolicy configures how requests are retried when Contentful responds
+// with a rate limit or server error. Requests are retried using the
+// transport.DefaultRetryPolicy unless configured otherwise, a nil policy
+// disables retries. The middleware configured with WithMiddleware wraps the
+// retries, so it sees every request once.
+func (c *Client) SetRetryPolicy(policy *transport.RetryPolicy) {
+	c.sling = c.sling.New().Doer(transport.Chain(transport.Retry(c.doer, policy), c.middleware...))
+}
+
+// Environment returns the identifier of the environment the client operates
+// on. An empty identifier refers to the master environment.
+func (c *Client) Environment() string {
+	return c.environment
+}
+
+// spacePath returns the path of a resource within a space, scoped to the
+// client's environment.
+func (c *Client) spacePath(spaceID string, format string, args ...interface{}) string {
+	resource := fmt.Sprintf(format, args...)
+	if c.environment == "" {
+		return fmt.Sprintf("spaces/%v/%v", spaceID, resource)
+	}
+
+	return fmt.Sprintf("spaces/%v/environments/%v/%v", spaceID, c.environment, resource)
+}
+
+// InEnvironment returns a shallow copy of the client whose entries, assets,
+// content types and locales are scoped to the given environment. The
+// identifier can also be an environment alias.
+//
+//	staging := client.InEnvironment("staging")
+//	entry, err := staging.FetchEntry(spaceID, entryID)
+func (c *Client) InEnvironment(environmentID string) *Client {
+	client := *c
+	client.environment = environmentID
+
+	return &client
+}
+
+// WithContext returns a shallow copy of the client whose requests are bound to
+// ctx. Cancelling ctx aborts rate limit waits as well as in-flight requests.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+
+	client := *c
+	client.ctx = ctx
+
+	return &client
+}
+
+// Context returns the context the client's requests are bound to. It defaults
+// to context.Background.
+func (c *Client) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+
+	return context.Background()
+}
+
+// wait blocks until the rate limiter permits another request or the client's
+// context is done.
+func (c *Client) wait() error {
+	ctx := c.Context()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if c.rl == nil {
+			return nil
+		}
+
+		ok, remaining := c.rl.Try()
+		if ok {
+			return nil
+		}
+
+		timer := time.NewTimer(remaining)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// do performs the request once the rate limiter permits it. The request is
+// bound to the client's context. Failed responses are decoded into failure
+// along with the status code and rate limit information, bodies that can't be
+// decoded are ignored.
+func (c *Client) do(req *http.Request, success interface{}, failure *ContentfulError) (*http.Response, error) {
+	if err := c.wait(); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := c.sling.Do(req.WithContext(c.Context()), success, failure)
+
+	transport.LogRequest(c.Context(), c.logger, req, resp, time.Since(start), err)
+
+	if resp != nil && resp.StatusCode >= http.StatusBadRequest && failure != nil {
+		err = nil
+		failure.StatusCode = resp.StatusCode
+		failure.RateLimitReset, _ = transport.RetryAfter(resp)
+
+		if failure.RequestID == "" {
+			failure.RequestID = resp.Header.Get("X-Contentful-Request-Id")
+		}
+	}
+
+	return resp, err
+}
+
 func contentTypeHeader(version string) string {
 	return fmt.Sprintf("application/vnd.contentful.management.%v+json", version)
 }
@@ -50,18 +206,24 @@ func authorizationHeader(accessToken string) string {
 	return fmt.Sprintf("Bearer %v", accessToken)
 }
 
-func handleError(reqErr error, err *models.Error) error {
+func handleError(reqErr error, err *ContentfulError) error {
 	if reqErr != nil {
 		return reqErr
 	}
 
-	if err.RequestID == "" && err.Message == "" {
+	if err.RequestID == "" && err.Message == "" && err.StatusCode < http.StatusBadRequest {
 		return nil
 	}
 
 	return err
 }
 
+// ContentfulError is returned when a Contentful API request fails. Use
+// errors.Is with the sentinel errors of the models package, such as
+// models.ErrNotFound, to tell failures apart and errors.As to access the status
+// code, request ID and validation problems.
+type ContentfulError = models.Error
+
 // Doer executes http requests.  It is implemented by *http.Client.  You can
 // wrap *http.Client with layers of Doers to form a stack of client-side
 // middleware.
@@ -1,9 +1,16 @@
 package management
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"io/ioutil"
+	"net/http"
 	"testing"
+	"time"
 
+	. "github.com/illyabusigin/contentful/models"
+	"github.com/illyabusigin/contentful/transport"
 	assert "github.com/stretchr/testify/require"
 	//expect "gopkg.in/gavv/httpexpect.v1"
 )
@@ -18,6 +25,133 @@ func TestNewClient(t *testing.T) {
 	assert.Equal(t, client.AccessToken, accessToken)
 }
 
+func TestNewWithOptions(t *testing.T) {
+	client := New(accessToken,
+		WithBaseURL("http://localhost:8080/contentful"),
+		WithEnvironment("staging"),
+		WithUserAgent("importer/1.0"),
+		WithPageSize(50),
+		WithRateLimit(0, 0),
+	)
+
+	assert.NotNil(t, client, "Client should not be nil")
+	assert.Nil(t, client.rl, "Rate limiting should be disabled")
+	assert.Equal(t, "staging", client.Environment())
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	result := client.QueryEntries("space123", nil, 100, 0)
+	req := doer.request
+
+	assert.Equal(t, errIntercept, result.Errors[0])
+	assert.Equal(t, "http://localhost:8080/contentful/spaces/space123/environments/staging/entries?limit=50&skip=0", req.URL.String())
+	assert.Equal(t, "importer/1.0", req.Header.Get("User-Agent"))
+	assert.Equal(t, "Bearer access_token", req.Header.Get("Authorization"))
+
+	// Space level resources are not scoped to the environment
+	client.FetchSpace("space123")
+	assert.Equal(t, "http://localhost:8080/contentful/spaces/space123", doer.request.URL.String())
+
+	// Other clients are not affected
+	other := NewClient(accessToken, version, nil)
+	assert.Equal(t, PaginationSizeLimit, other.pageSize)
+	assert.Equal(t, "", other.Environment())
+}
+
+func TestWithContext(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.Equal(t, context.Background(), client.Context())
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scoped := client.WithContext(ctx)
+	assert.Equal(t, ctx, scoped.Context())
+	assert.Equal(t, context.Background(), client.Context(), "Original client should not be modified")
+
+	_, err := scoped.FetchSpace("space123")
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, ctx, doer.request.Context())
+
+	// Cancelled contexts abort before the request is performed
+	cancel()
+	doer.request = nil
+
+	_, err = scoped.FetchSpace("space123")
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, doer.request)
+}
+
+type recordingLogger struct {
+	levels   []transport.Level
+	messages []string
+	fields   [][]transport.Field
+}
+
+func (l *recordingLogger) Log(ctx context.Context, level transport.Level, msg string, fields ...transport.Field) {
+	l.levels = append(l.levels, level)
+	l.messages = append(l.messages, msg)
+	l.fields = append(l.fields, fields)
+}
+
+func TestWithStructuredLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	client := New(accessToken, WithStructuredLogger(logger), WithRateLimit(0, 0))
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.response = &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"X-Contentful-Request-Id": []string{"req-1"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"name":"Space"}`)),
+	}
+	client.sling = client.sling.New().Doer(doer)
+
+	_, err := client.FetchSpace("space123")
+	assert.Nil(t, err)
+	assert.Equal(t, []transport.Level{transport.LevelDebug}, logger.levels)
+	assert.Equal(t, []string{transport.RequestMessage}, logger.messages)
+	assert.Contains(t, logger.fields[0], transport.Field{Key: "path", Value: "/spaces/space123"})
+	assert.Contains(t, logger.fields[0], transport.Field{Key: "status", Value: 200})
+	assert.Contains(t, logger.fields[0], transport.Field{Key: "request_id", Value: "req-1"})
+}
+
+func TestWithDoerAndMiddleware(t *testing.T) {
+	doer := &interceptor{}
+	doer.err = errIntercept
+
+	requests := 0
+	client := New(accessToken,
+		WithDoer(doer),
+		WithRateLimit(0, 0),
+		WithRetryPolicy(nil),
+		WithMiddleware(
+			transport.Headers(http.Header{"X-Team": []string{"content"}}),
+			transport.RequestID(func() string { return "req-1" }),
+			transport.Metrics(func(req *http.Request, resp *http.Response, duration time.Duration, err error) {
+				requests++
+			}),
+		))
+
+	_, err := client.FetchSpace("space123")
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, "content", doer.request.Header.Get("X-Team"))
+	assert.Equal(t, "req-1", doer.request.Header.Get(transport.RequestIDHeader))
+	assert.Equal(t, "Bearer access_token", doer.request.Header.Get("Authorization"))
+	assert.Equal(t, 1, requests)
+
+	// The middleware is kept when the retry policy changes
+	client.SetRetryPolicy(&transport.RetryPolicy{MaxAttempts: 2})
+	client.FetchSpace("space123")
+	assert.Equal(t, 2, requests)
+}
+
 func TestContentTypeHeader(t *testing.T) {
 	header := contentTypeHeader("v1")
 	assert.Equal(t, "application/vnd.contentful.management.v1+json", header)
@@ -43,3 +177,80 @@ func TestContentfulError(t *testing.T) {
 	err := ContentfulError{Message: "Something went wrong"}
 	assert.Equal(t, err.Message, err.Error())
 }
+
+func TestTypedErrors(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	client.sling = client.sling.New().Doer(doer)
+
+	respond := func(status int, header http.Header, body string) {
+		doer.response = &http.Response{
+			StatusCode: status,
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+	}
+
+	// Errors are identified by their sys.id
+	respond(http.StatusConflict, http.Header{}, `{"sys": {"type": "Error", "id": "VersionMismatch"}, "requestId": "req123"}`)
+	_, err := client.UpdateEntry(goodEntry)
+
+	assert.True(t, errors.Is(err, ErrVersionMismatch))
+	assert.False(t, errors.Is(err, ErrNotFound))
+
+	var contentfulError *ContentfulError
+	assert.True(t, errors.As(err, &contentfulError))
+	assert.Equal(t, http.StatusConflict, contentfulError.StatusCode)
+	assert.Equal(t, "req123", contentfulError.RequestID)
+	assert.Equal(t, "VersionMismatch (request ID: req123)", err.Error())
+
+	// Errors without a body fall back to the status code
+	respond(http.StatusNotFound, http.Header{"X-Contentful-Request-Id": []string{"req456"}}, "")
+	_, err = client.FetchEntry("space123", "entry123")
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.As(err, &contentfulError))
+	assert.Equal(t, "req456", contentfulError.RequestID)
+
+	respond(http.StatusBadGateway, http.Header{}, "<html><body>Bad Gateway</body></html>")
+	_, err = client.FetchEntry("space123", "entry123")
+	assert.True(t, errors.Is(err, ErrServerError))
+	assert.True(t, errors.As(err, &contentfulError))
+	assert.Equal(t, http.StatusBadGateway, contentfulError.StatusCode)
+
+	// Rate limit reset
+	respond(http.StatusTooManyRequests, http.Header{"X-Contentful-Ratelimit-Reset": []string{"2"}}, `{"sys": {"type": "Error", "id": "RateLimitExceeded"}, "message": "You have exceeded the rate limit"}`)
+	_, err = client.FetchEntry("space123", "entry123")
+
+	assert.True(t, errors.Is(err, ErrRateLimitExceeded))
+	assert.True(t, errors.As(err, &contentfulError))
+	assert.Equal(t, 2*time.Second, contentfulError.RateLimitReset)
+
+	// Validation problems
+	respond(http.StatusUnprocessableEntity, http.Header{}, `{
+    "sys": {"type": "Error", "id": "ValidationFailed"},
+    "message": "Validation error",
+    "details": {
+        "errors": [
+            {"name": "required", "path": ["fields", "title"], "details": "The property \"title\" is required here"},
+            {"name": "size", "path": ["fields", "slug", "en-US"], "value": "a", "min": 3}
+        ]
+    }
+}`)
+	_, err = client.UpdateEntry(goodEntry)
+
+	assert.True(t, errors.Is(err, ErrValidationFailed))
+	assert.True(t, errors.As(err, &contentfulError))
+
+	validationErrors := contentfulError.ValidationErrors()
+	assert.Len(t, validationErrors, 2)
+	assert.Equal(t, "required", validationErrors[0].Name)
+	assert.Equal(t, "title", validationErrors[0].Field())
+	assert.Equal(t, "", validationErrors[0].Locale())
+	assert.Equal(t, "slug", validationErrors[1].Field())
+	assert.Equal(t, "en-US", validationErrors[1].Locale())
+	assert.Equal(t, float64(3), validationErrors[1].Min)
+}
@@ -21,8 +21,6 @@ func (c *Client) FetchContentTypes(spaceID string, published bool, limit int, of
 		limit = 100
 	}
 
-	c.rl.Wait()
-
 	type contentTypesResponse struct {
 		*Pagination
 		Items []*ContentType `json:"items"`
@@ -32,10 +30,10 @@ func (c *Client) FetchContentTypes(spaceID string, published bool, limit int, of
 	contentfulError := new(Error)
 	path := func() string {
 		if published {
-			return fmt.Sprintf("spaces/%v/public/content_types", spaceID)
+			return c.spacePath(spaceID, "public/content_types")
 		}
 
-		return fmt.Sprintf("spaces/%v/content_types", spaceID)
+		return c.spacePath(spaceID, "content_types")
 	}
 
 	req, err := c.sling.New().
@@ -52,7 +50,7 @@ func (c *Client) FetchContentTypes(spaceID string, published bool, limit int, of
 	q.Set("limit", fmt.Sprintf("%v", limit))
 	req.URL.RawQuery = q.Encode()
 
-	_, err = c.sling.Do(req, results, contentfulError)
+	_, err = c.do(req, results, contentfulError)
 
 	return results.Items, results.Pagination, handleError(err, contentfulError)
 
@@ -69,20 +67,20 @@ func (c *Client) CreateContentType(contentType *ContentType) (created *ContentTy
 		return
 	}
 
-	c.rl.Wait()
-
 	created = new(ContentType)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/content_types/%v", contentType.Space.ID, contentType.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(contentType.Space.ID, "content_types/%v", contentType.ID)
+	req, err := c.sling.New().
 		Put(path).
 		Set("X-Contentful-Version", fmt.Sprintf("%v", contentType.Version)).
 		BodyJSON(contentType).
-		Receive(created, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
 
-	fmt.Println("created:", created)
-	fmt.Println("err", err)
-	fmt.Println("contentFulError", contentfulError)
+	_, err = c.do(req, created, contentfulError)
 
 	return created, handleError(err, contentfulError)
 }
@@ -95,12 +93,16 @@ func (c *Client) FetchContentType(spaceID string, contentTypeID string) (content
 		return
 	}
 
-	c.rl.Wait()
-
 	contentType = new(ContentType)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/content_types/%v", spaceID, contentTypeID)
-	_, err = c.sling.New().Get(path).Receive(contentType, contentfulError)
+	path := c.spacePath(spaceID, "content_types/%v", contentTypeID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, contentType, contentfulError)
 
 	return contentType, handleError(err, contentfulError)
 }
@@ -113,13 +115,17 @@ func (c *Client) UpdateContentType(contentType *ContentType) (updated *ContentTy
 // DeleteContentType will delete a content type. Before you can delete a content
 // type you need to deactivate it.
 func (c *Client) DeleteContentType(spaceID string, contentTypeID string) (err error) {
-	c.rl.Wait()
-
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/content_types/%v", spaceID, contentTypeID)
-	_, err = c.sling.New().
+	path := c.spacePath(spaceID, "content_types/%v", contentTypeID)
+	req, err := c.sling.New().
 		Delete(path).
-		Receive(nil, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, nil, contentfulError)
 
 	return handleError(err, contentfulError)
 }
@@ -134,15 +140,19 @@ func (c *Client) ActivateContentType(contentType *ContentType) (activated *Conte
 		return
 	}
 
-	c.rl.Wait()
-
 	activated = new(ContentType)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/content_types/%v/published", contentType.Space.ID, contentType.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(contentType.Space.ID, "content_types/%v/published", contentType.ID)
+	req, err := c.sling.New().
 		Put(path).
 		Set("X-Contentful-Version", fmt.Sprintf("%v", contentType.Version)).
-		Receive(activated, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, activated, contentfulError)
 
 	return activated, handleError(err, contentfulError)
 }
@@ -157,15 +167,19 @@ func (c *Client) DeactivateContentType(contentType *ContentType) (deactivated *C
 		return
 	}
 
-	c.rl.Wait()
-
 	deactivated = new(ContentType)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/content_types/%v/published", contentType.Space.ID, contentType.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(contentType.Space.ID, "content_types/%v/published", contentType.ID)
+	req, err := c.sling.New().
 		Delete(path).
 		Set("X-Contentful-Version", fmt.Sprintf("%v", contentType.Version)).
-		Receive(deactivated, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, deactivated, contentfulError)
 
 	return deactivated, handleError(err, contentfulError)
 }
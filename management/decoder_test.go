@@ -0,0 +1,174 @@
+package management
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/illyabusigin/contentful/models"
+	assert "github.com/stretchr/testify/require"
+)
+
+type testAuthor struct {
+	ID   string `contentful:"sys.id"`
+	Name string `contentful:"name"`
+}
+
+type testImage struct {
+	ID    string    `contentful:"sys.id"`
+	Title string    `contentful:"title"`
+	File  AssetData `contentful:"file"`
+}
+
+type testPost struct {
+	ID          string      `contentful:"sys.id"`
+	Version     int         `contentful:"sys.version"`
+	ContentType string      `contentful:"sys.contentType"`
+	Title       string      `contentful:"title"`
+	Views       int         `contentful:"views"`
+	Published   time.Time   `contentful:"publishDate"`
+	Location    GeoPoint    `contentful:"location"`
+	Author      testAuthor  `contentful:"author"`
+	Reviewer    *testAuthor `contentful:"reviewer"`
+	EditorID    string      `contentful:"editor,link"`
+	Image       *testImage  `contentful:"image"`
+	Tags        []string    `contentful:"tags"`
+	Meta        struct {
+		Keywords []string `json:"keywords"`
+	} `contentful:"meta"`
+	Ignored string
+}
+
+const decodeEntriesJSON = `{
+	"items": [{
+		"sys": {"id": "post1", "version": 4, "contentType": {"sys": {"type": "Link", "linkType": "ContentType", "id": "post"}}},
+		"fields": {
+			"title": {"en-US": "Hello", "de-DE": "Hallo"},
+			"views": {"en-US": 42},
+			"publishDate": {"en-US": "2017-05-04T10:30"},
+			"location": {"en-US": {"lat": 52.52, "lon": 13.4}},
+			"author": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "author1"}}},
+			"reviewer": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "author2"}}},
+			"editor": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "author2"}}},
+			"image": {"en-US": {"sys": {"type": "Link", "linkType": "Asset", "id": "image1"}}},
+			"tags": {"en-US": ["go", "cms"]},
+			"meta": {"en-US": {"keywords": ["headless"]}}
+		}
+	}],
+	"includes": {
+		"Entry": [
+			{"sys": {"id": "author1"}, "fields": {"name": {"en-US": "Ada"}}},
+			{"sys": {"id": "author2"}, "fields": {"name": {"en-US": "Grace", "de-DE": "Grace H."}}}
+		],
+		"Asset": [
+			{"sys": {"id": "image1"}, "fields": {"title": {"en-US": "Logo"}, "file": {"en-US": {"url": "//images.ctfassets.net/logo.png", "fileName": "logo.png", "contentType": "image/png"}}}}
+		]
+	}
+}`
+
+func TestDecodeEntry(t *testing.T) {
+	var response struct {
+		Items    []*Entry  `json:"items"`
+		Includes *Includes `json:"includes"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(decodeEntriesJSON), &response))
+
+	result := &QueryEntriesResult{Entries: response.Items, Includes: response.Includes}
+	result.ResolveLinks(2)
+
+	post := testPost{}
+	err := DecodeEntry(result.Entries[0], &post, "de-DE", "en-US")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "post1", post.ID)
+	assert.Equal(t, 4, post.Version)
+	assert.Equal(t, "post", post.ContentType)
+	assert.Equal(t, "Hallo", post.Title)
+	assert.Equal(t, 42, post.Views, "Missing locales should fall back to the default locale")
+	assert.Equal(t, time.Date(2017, 5, 4, 10, 30, 0, 0, time.UTC), post.Published)
+	assert.Equal(t, GeoPoint{Lat: 52.52, Lon: 13.4}, post.Location)
+	assert.Equal(t, testAuthor{ID: "author1", Name: "Ada"}, post.Author)
+	assert.Equal(t, &testAuthor{ID: "author2", Name: "Grace H."}, post.Reviewer)
+	assert.Equal(t, "author2", post.EditorID)
+	assert.Equal(t, "Logo", post.Image.Title)
+	assert.Equal(t, "//images.ctfassets.net/logo.png", post.Image.File.URL)
+	assert.Equal(t, []string{"go", "cms"}, post.Tags)
+	assert.Equal(t, []string{"headless"}, post.Meta.Keywords)
+
+	// Unresolved links are looked up in the includes or only carry the identifier
+	assert.Nil(t, json.Unmarshal([]byte(decodeEntriesJSON), &response))
+
+	post = testPost{}
+	decoder := NewDecoder("en-US", "")
+	assert.Nil(t, decoder.Decode(response.Items[0], &post))
+	assert.Equal(t, testAuthor{ID: "author1"}, post.Author)
+
+	decoder.Includes = response.Includes
+	assert.Nil(t, decoder.Decode(response.Items[0], &post))
+	assert.Equal(t, testAuthor{ID: "author1", Name: "Ada"}, post.Author)
+
+	// Type mismatches
+	var invalid struct {
+		Title int `contentful:"title"`
+	}
+	assert.NotNil(t, DecodeEntry(response.Items[0], &invalid, "en-US", ""))
+	assert.NotNil(t, DecodeEntry(response.Items[0], invalid, "en-US", ""), "Non-pointer targets should be rejected")
+}
+
+func TestEncodeEntry(t *testing.T) {
+	type post struct {
+		ID        string     `contentful:"sys.id"`
+		Title     string     `contentful:"title"`
+		Published time.Time  `contentful:"publishDate"`
+		Location  *GeoPoint  `contentful:"location"`
+		Author    testAuthor `contentful:"author"`
+		ImageIDs  []string   `contentful:"images,asset"`
+		Summary   string     `contentful:"summary,omitempty"`
+	}
+
+	fields, err := EncodeEntry(&post{
+		ID:        "post1",
+		Title:     "Hello",
+		Published: time.Date(2017, 5, 4, 10, 30, 0, 0, time.UTC),
+		Location:  &GeoPoint{Lat: 52.52, Lon: 13.4},
+		Author:    testAuthor{ID: "author1", Name: "Ada"},
+		ImageIDs:  []string{"image1"},
+	}, "en-US")
+
+	assert.Nil(t, err)
+
+	expectedJSON := `{
+		"title": {"en-US": "Hello"},
+		"publishDate": {"en-US": "2017-05-04T10:30:00Z"},
+		"location": {"en-US": {"lat": 52.52, "lon": 13.4}},
+		"author": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "author1"}}},
+		"images": {"en-US": [{"sys": {"type": "Link", "linkType": "Asset", "id": "image1"}}]}
+	}`
+	data, _ := json.Marshal(fields)
+	assert.JSONEq(t, expectedJSON, string(data))
+
+	// Merge into an existing entry keeps other locales
+	entry := &Entry{Fields: EntryFields{"title": map[string]interface{}{"de-DE": "Hallo"}}}
+	entry.MergeFields(fields)
+	assert.Equal(t, map[string]interface{}{"de-DE": "Hallo", "en-US": "Hello"}, entry.Fields["title"])
+}
+
+func TestEncodeUnsetLinks(t *testing.T) {
+	type post struct {
+		Title    string     `contentful:"title"`
+		AuthorID string     `contentful:"author,link"`
+		Editor   testAuthor `contentful:"editor"`
+		ImageIDs []string   `contentful:"images,asset"`
+	}
+
+	fields, err := EncodeEntry(&post{Title: "Hello", ImageIDs: []string{"", "image1"}}, "en-US")
+	assert.Nil(t, err)
+
+	// Links without an ID are left out instead of being sent with an empty ID
+	expectedJSON := `{
+		"title": {"en-US": "Hello"},
+		"images": {"en-US": [{"sys": {"type": "Link", "linkType": "Asset", "id": "image1"}}]}
+	}`
+	data, _ := json.Marshal(fields)
+	assert.JSONEq(t, expectedJSON, string(data))
+}
@@ -8,6 +8,11 @@ import (
 
 // QueryEntries returns all entries for the given space and parameters.
 func (c *Client) QueryEntries(spaceID string, params map[string]string, limit int, offset int) (result *QueryEntriesResult) {
+	return c.SearchEntries(spaceID, NewQueryFromParams(params), limit, offset)
+}
+
+// SearchEntries returns all entries for the given space matching the query.
+func (c *Client) SearchEntries(spaceID string, query *Query, limit int, offset int) (result *QueryEntriesResult) {
 	result = &QueryEntriesResult{
 		Entries: []*Entry{},
 		Includes: &Includes{
@@ -23,17 +28,19 @@ func (c *Client) QueryEntries(spaceID string, params map[string]string, limit in
 		return
 	}
 
+	if query == nil {
+		query = NewQuery()
+	}
+
 	if limit < 0 {
 		result.Errors = append(result.Errors, fmt.Errorf("QueryEntries failed. Limit must be greater than 0"))
 		return
 	}
 
-	if limit > PaginationSizeLimit {
-		limit = PaginationSizeLimit
+	if limit > c.pageSize {
+		limit = c.pageSize
 	}
 
-	c.rl.Wait()
-
 	type entriesResponse struct {
 		*Pagination
 		Items    []*Entry  `json:"items"`
@@ -48,7 +55,7 @@ func (c *Client) QueryEntries(spaceID string, params map[string]string, limit in
 	}
 
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/entries", spaceID)
+	path := c.spacePath(spaceID, "entries")
 	req, err := c.sling.New().
 		Get(path).
 		Request()
@@ -59,8 +66,8 @@ func (c *Client) QueryEntries(spaceID string, params map[string]string, limit in
 
 	// Add query parameters
 	q := req.URL.Query()
-	for k, v := range params {
-		q.Set(k, v)
+	for k, v := range query.Values() {
+		q[k] = v
 	}
 
 	q.Set("skip", fmt.Sprintf("%v", offset))
@@ -68,7 +75,7 @@ func (c *Client) QueryEntries(spaceID string, params map[string]string, limit in
 	req.URL.RawQuery = q.Encode()
 
 	// Perform request
-	_, err = c.sling.Do(req, response, contentfulError)
+	_, err = c.do(req, response, contentfulError)
 
 	result.Pagination = response.Pagination
 	result.Includes = response.Includes
@@ -88,18 +95,22 @@ func (c *Client) FetchEntry(spaceID string, entryID string) (entry *Entry, err e
 		return
 	}
 
-	c.rl.Wait()
-
 	entry = new(Entry)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/entries/%v", spaceID, entryID)
-	_, err = c.sling.New().Get(path).Receive(entry, contentfulError)
+	path := c.spacePath(spaceID, "entries/%v", entryID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, entry, contentfulError)
 
 	return entry, handleError(err, contentfulError)
 }
 
-// CreateEntry will create a new entry with an ID specified by the user or
-// generated by the system
+// CreateEntry will create a new entry with an ID specified by the user in
+// NewEntry.ID or generated by the system
 func (c *Client) CreateEntry(entry *NewEntry, contentType *ContentType) (created *Entry, err error) {
 	if entry == nil || contentType == nil {
 		err = fmt.Errorf("CreateEntry failed, entry and contentType cannot be nil!")
@@ -114,16 +125,26 @@ func (c *Client) CreateEntry(entry *NewEntry, contentType *ContentType) (created
 		return
 	}
 
-	c.rl.Wait()
-
 	created = new(Entry)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/entries", contentType.Space.ID)
-	_, err = c.sling.New().
-		Post(path).
+	builder := c.sling.New().Post(c.spacePath(contentType.Space.ID, "entries"))
+
+	// Entries with an ID are created with a PUT, which fails with
+	// ErrVersionMismatch if the entry already exists
+	if entry.ID != "" {
+		builder = c.sling.New().Put(c.spacePath(contentType.Space.ID, "entries/%v", entry.ID))
+	}
+
+	req, err := builder.
 		Set("X-Contentful-Content-Type", contentType.ID).
 		BodyJSON(entry).
-		Receive(created, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, created, contentfulError)
 
 	return created, handleError(err, contentfulError)
 }
@@ -140,16 +161,20 @@ func (c *Client) UpdateEntry(entry *Entry) (updated *Entry, err error) {
 		return
 	}
 
-	c.rl.Wait()
-
 	updated = new(Entry)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/entries/%v", entry.Space.ID, entry.System.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(entry.Space.ID, "entries/%v", entry.System.ID)
+	req, err := c.sling.New().
 		Put(path).
 		Set("X-Contentful-Version", fmt.Sprintf("%v", entry.System.Version)).
 		BodyJSON(entry).
-		Receive(updated, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, updated, contentfulError)
 
 	return updated, handleError(err, contentfulError)
 }
@@ -157,13 +182,17 @@ func (c *Client) UpdateEntry(entry *Entry) (updated *Entry, err error) {
 // DeleteEntry will delete the specified entry
 func (c *Client) DeleteEntry(entryID string, spaceID string) (err error) {
 
-	c.rl.Wait()
-
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/entries/%v", spaceID, entryID)
-	_, err = c.sling.New().
+	path := c.spacePath(spaceID, "entries/%v", entryID)
+	req, err := c.sling.New().
 		Delete(path).
-		Receive(nil, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, nil, contentfulError)
 
 	return handleError(err, contentfulError)
 }
@@ -179,15 +208,19 @@ func (c *Client) PublishEntry(entry *Entry) (published *Entry, err error) {
 		return
 	}
 
-	c.rl.Wait()
-
 	published = new(Entry)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/entries/%v/published", entry.Space.ID, entry.System.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(entry.Space.ID, "entries/%v/published", entry.System.ID)
+	req, err := c.sling.New().
 		Put(path).
 		Set("X-Contentful-Version", fmt.Sprintf("%v", entry.System.Version)).
-		Receive(published, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, published, contentfulError)
 
 	return published, handleError(err, contentfulError)
 }
@@ -203,14 +236,18 @@ func (c *Client) UnpublishEntry(entry *Entry) (unpublished *Entry, err error) {
 		return
 	}
 
-	c.rl.Wait()
-
 	unpublished = new(Entry)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/entries/%v/published", entry.Space.ID, entry.System.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(entry.Space.ID, "entries/%v/published", entry.System.ID)
+	req, err := c.sling.New().
 		Delete(path).
-		Receive(unpublished, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, unpublished, contentfulError)
 
 	return unpublished, handleError(err, contentfulError)
 }
@@ -227,14 +264,18 @@ func (c *Client) ArchiveEntry(entry *Entry) (archived *Entry, err error) {
 		return
 	}
 
-	c.rl.Wait()
-
 	archived = new(Entry)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/entries/%v/archived", entry.Space.ID, entry.System.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(entry.Space.ID, "entries/%v/archived", entry.System.ID)
+	req, err := c.sling.New().
 		Put(path).
-		Receive(archived, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, archived, contentfulError)
 
 	return archived, handleError(err, contentfulError)
 }
@@ -250,14 +291,27 @@ func (c *Client) UnarchiveEntry(entry *Entry) (unarchived *Entry, err error) {
 		return
 	}
 
-	c.rl.Wait()
-
 	unarchived = new(Entry)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/entries/%v/archived", entry.Space.ID, entry.System.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(entry.Space.ID, "entries/%v/archived", entry.System.ID)
+	req, err := c.sling.New().
 		Delete(path).
-		Receive(unarchived, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, unarchived, contentfulError)
 
 	return unarchived, handleError(err, contentfulError)
 }
+
+// ResolveLinks replaces the links inside the fields of the result entries with
+// the matching *Entry and *Asset from the result includes, following links up
+// to the given include depth. Links that cannot be resolved are added to
+// result.Errors as notResolvable ContentErrors. Resolved results with cyclic
+// links cannot be marshalled, see QueryEntriesResult.ResolveLinks.
+func ResolveLinks(result *QueryEntriesResult, depth int) {
+	result.ResolveLinks(depth)
+}
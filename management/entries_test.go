@@ -1,9 +1,12 @@
 package management
 
 import (
+	"bytes"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
 	. "github.com/illyabusigin/contentful/models"
 	assert "github.com/stretchr/testify/require"
@@ -109,9 +112,147 @@ func TestQueryEntriesRequest(t *testing.T) {
 	assert.Equal(t, "https://api.contentful.com/spaces/space123/entries?include=all&limit=100&skip=0", req.URL.String())
 }
 
+func TestSearchEntriesRequest(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	query := NewQuery().
+		ContentType("post").
+		In("fields.slug", "hello", "world").
+		Equal("fields.tags", "cats").
+		Equal("fields.tags", "dogs").
+		Exists("fields.image", true).
+		Order("sys.createdAt").
+		OrderReversed("fields.title").
+		Include(2)
+
+	result := client.SearchEntries("space123", query, 100, 0)
+	err := result.Errors[0]
+	req := doer.request
+
+	assert.Equal(t, err, errIntercept)
+	assert.NotNil(t, req)
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "/spaces/space123/entries", req.URL.Path)
+	assert.Equal(t, "post", req.URL.Query().Get("content_type"))
+	assert.Equal(t, "hello,world", req.URL.Query().Get("fields.slug[in]"))
+	assert.Equal(t, []string{"cats", "dogs"}, req.URL.Query()["fields.tags"])
+	assert.Equal(t, "true", req.URL.Query().Get("fields.image[exists]"))
+	assert.Equal(t, "sys.createdAt,-fields.title", req.URL.Query().Get("order"))
+	assert.Equal(t, "2", req.URL.Query().Get("include"))
+
+	// Nil queries are allowed
+	result = client.SearchEntries("space123", nil, 100, 0)
+	assert.Equal(t, result.Errors[0], errIntercept)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/entries?limit=100&skip=0", doer.request.URL.String())
+}
+
+func TestQueryNilTime(t *testing.T) {
+	// Nil times are skipped instead of panicking
+	var publishedAt *time.Time
+	createdAt := time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	values := NewQuery().
+		Equal("sys.publishedAt", publishedAt).
+		LessThan("sys.publishedAt", publishedAt).
+		In("sys.createdAt", publishedAt, &createdAt).
+		Values()
+
+	assert.Equal(t, url.Values{"sys.createdAt[in]": []string{"2017-01-02T03:04:05Z"}}, values)
+}
+
+func TestQueryNoValues(t *testing.T) {
+	// Filters without values are skipped instead of matching an empty value
+	ids := []interface{}{}
+	values := NewQuery().
+		In("sys.id").
+		NotIn("sys.id").
+		All("fields.tags").
+		Values()
+
+	assert.Empty(t, values)
+	assert.Empty(t, NewQuery().In("sys.id", ids...).Values())
+}
+
 func TestQueryEntriesResponseSuccess(t *testing.T) {
 }
 
+func TestResolveLinks(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	client.sling = client.sling.New().Doer(doer)
+	header := http.Header{"Content-Type": []string{"application/vnd.contentful.management.v1+json"}}
+
+	doer.response = &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body: ioutil.NopCloser(bytes.NewBuffer([]byte(`{
+    "total": 1,
+    "skip": 0,
+    "limit": 100,
+    "items": [{
+        "sys": {"id": "cat", "type": "Entry"},
+        "fields": {
+            "friends": {"en-US": [
+                {"sys": {"type": "Link", "linkType": "Entry", "id": "dog"}},
+                {"sys": {"type": "Link", "linkType": "Entry", "id": "mouse"}}
+            ]},
+            "image": {"en-US": {"sys": {"type": "Link", "linkType": "Asset", "id": "catImage"}}}
+        }
+    }],
+    "includes": {
+        "Entry": [{
+            "sys": {"id": "dog", "type": "Entry"},
+            "fields": {
+                "bestFriend": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "cat"}}}
+            }
+        }],
+        "Asset": [{
+            "sys": {"id": "catImage", "type": "Asset"},
+            "fields": {"title": {"en-US": "Cat"}}
+        }]
+    }
+}`))),
+	}
+
+	result := client.QueryEntries("space123", nil, 100, 0)
+	assert.Empty(t, result.Errors)
+	assert.Len(t, result.Entries, 1)
+
+	ResolveLinks(result, 2)
+
+	cat := result.Entries[0]
+	friends := cat.Fields["friends"].(map[string]interface{})["en-US"].([]interface{})
+	dog, ok := friends[0].(*Entry)
+	assert.True(t, ok, "Link to dog should be resolved")
+	assert.Equal(t, "dog", dog.ID)
+
+	image, ok := cat.Fields["image"].(map[string]interface{})["en-US"].(*Asset)
+	assert.True(t, ok, "Link to asset should be resolved")
+	assert.Equal(t, "Cat", image.Fields.Title["en-US"])
+
+	// Cycles resolve to the same entry
+	bestFriend := dog.Fields["bestFriend"].(map[string]interface{})["en-US"]
+	assert.Equal(t, cat, bestFriend)
+
+	// Unresolvable links are left in place and reported
+	_, ok = friends[1].(map[string]interface{})
+	assert.True(t, ok, "Link to mouse should not be resolved")
+	assert.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Error(), "mouse")
+
+	contentError, ok := result.Errors[0].(*ContentError)
+	assert.True(t, ok, "Unresolvable links should be reported as ContentErrors")
+	assert.Equal(t, "notResolvable", contentError.Sys.ID)
+}
+
 func TestFetchEntryRequest(t *testing.T) {
 	client := NewClient(accessToken, version, nil)
 	assert.NotNil(t, client, "Client should not be nil")
@@ -202,6 +343,18 @@ func TestCreateEntryRequest(t *testing.T) {
 	requestJSON, _ := ioutil.ReadAll(req.Body)
 	assert.JSONEq(t, expectedJSON, string(requestJSON))
 
+	// Entries with an ID are created with a PUT
+	newEntry.ID = "cat"
+	client.CreateEntry(newEntry, newContentType)
+	req = doer.request
+
+	assert.Equal(t, "https://api.contentful.com/spaces/abc123/entries/cat", req.URL.String())
+	assert.Equal(t, "TestType", req.Header.Get("X-Contentful-Content-Type"))
+	assert.Equal(t, http.MethodPut, req.Method)
+
+	requestJSON, _ = ioutil.ReadAll(req.Body)
+	assert.JSONEq(t, expectedJSON, string(requestJSON))
+
 	// nil entry
 	_, err = client.CreateEntry(nil, nil)
 	assert.NotNil(t, err)
@@ -0,0 +1,162 @@
+package management
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/illyabusigin/contentful/models"
+	assert "github.com/stretchr/testify/require"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func integer(v int) *int {
+	return &v
+}
+
+func validatorContentType() *ContentType {
+	minDate := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	contentType := &ContentType{
+		Name: "Post",
+		Fields: []Field{
+			{ID: "title", Type: ShortText, Required: true, Localized: true, Validations: []FieldValidation{
+				{Size: &SizeFieldValidation{Min: float(3), Max: float(20)}, Message: "Title must be between 3 and 20 characters"},
+			}},
+			{ID: "slug", Type: ShortText, Validations: []FieldValidation{
+				{RegularExpression: &RegExFieldValidation{Pattern: "^[a-z0-9-]+$"}},
+			}},
+			{ID: "category", Type: ShortText, Validations: []FieldValidation{{In: []interface{}{"news", "blog"}}}},
+			{ID: "rating", Type: Integer, Validations: []FieldValidation{{Range: &RangeFieldValidation{Min: integer(1), Max: integer(5)}}}},
+			{ID: "publishDate", Type: Date, Validations: []FieldValidation{{DateRange: &DateRangeFieldValidation{Min: &minDate}}}},
+			{ID: "author", Type: LinkType, LinkType: "Entry", Validations: []FieldValidation{{LinkContentTypes: []string{"author"}}}},
+			{ID: "image", Type: LinkType, LinkType: "Asset", Validations: []FieldValidation{
+				{LinkMIMETypeGroup: []string{"image"}},
+				{AssetImageValidation: &AssetImageValidation{Width: &SizeFieldValidation{Max: float(1000)}}},
+			}},
+			{ID: "tags", Type: Array, Items: &Field{Type: ShortText, Validations: []FieldValidation{{In: []interface{}{"go", "cms"}}}},
+				Validations: []FieldValidation{{Size: &SizeFieldValidation{Max: float(2)}}}},
+		},
+	}
+	contentType.ID = "post"
+
+	return contentType
+}
+
+func validatorLocales() []*Locale {
+	return []*Locale{
+		{Code: "en-US", Default: true},
+		{Code: "de-DE"},
+		{Code: "fr-FR", Optional: true},
+	}
+}
+
+func TestEntryValidator(t *testing.T) {
+	includes := &Includes{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"Entry": [{"sys": {"id": "tag1", "contentType": {"sys": {"type": "Link", "linkType": "ContentType", "id": "tag"}}}}],
+		"Asset": [{"sys": {"id": "pdf1"}, "fields": {"file": {"en-US": {"contentType": "application/pdf", "fileName": "a.pdf"}}}},
+		          {"sys": {"id": "img1"}, "fields": {"file": {"en-US": {"contentType": "image/png", "fileName": "a.png", "details": {"image": {"width": 2000, "height": 100}}}}}}]
+	}`), includes))
+
+	validator := NewEntryValidator(validatorContentType(), validatorLocales())
+	validator.Includes = includes
+
+	valid := EntryFields{
+		"title":       map[string]interface{}{"en-US": "Hello", "de-DE": "Hallo"},
+		"slug":        map[string]interface{}{"en-US": "hello-world"},
+		"category":    map[string]interface{}{"en-US": "news"},
+		"rating":      map[string]interface{}{"en-US": float64(4)},
+		"publishDate": map[string]interface{}{"en-US": "2017-05-04T10:30"},
+		"tags":        map[string]interface{}{"en-US": []interface{}{"go"}},
+	}
+	assert.Nil(t, validator.Validate(valid))
+
+	link := func(linkType string, id string) map[string]interface{} {
+		return map[string]interface{}{"sys": map[string]interface{}{"type": "Link", "linkType": linkType, "id": id}}
+	}
+
+	invalid := EntryFields{
+		"title":       map[string]interface{}{"en-US": "Hi", "fr-FR": "Salut"},
+		"slug":        map[string]interface{}{"en-US": "Hello World", "de-DE": "hallo"},
+		"category":    map[string]interface{}{"en-US": "sports"},
+		"rating":      map[string]interface{}{"en-US": 4.5},
+		"publishDate": map[string]interface{}{"en-US": "2016-05-04"},
+		"author":      map[string]interface{}{"en-US": link("Entry", "tag1")},
+		"image":       map[string]interface{}{"en-US": link("Asset", "pdf1")},
+		"tags":        map[string]interface{}{"en-US": []interface{}{"go", "rust", "cms"}},
+		"body":        map[string]interface{}{"en-US": "Unknown"},
+	}
+
+	err := validator.Validate(invalid)
+	assert.True(t, errors.Is(err, ErrValidationFailed))
+
+	fieldErrors := err.(FieldErrors)
+	problems := map[string]string{}
+	for _, fieldError := range fieldErrors {
+		problems[fieldError.Field+"/"+fieldError.Locale+"/"+fieldError.Validation] = fieldError.Message
+	}
+
+	assert.Equal(t, map[string]string{
+		"title/en-US/size":              "Title must be between 3 and 20 characters",
+		"title/de-DE/required":          "value is required",
+		"slug/en-US/regexp":             "regexp validation failed",
+		"slug/de-DE/localized":          "field is not localized, only en-US is allowed",
+		"category/en-US/in":             "in validation failed",
+		"rating/en-US/type":             "expected integer, got 4.5",
+		"publishDate/en-US/dateRange":   "dateRange validation failed",
+		"author/en-US/linkContentType":  "linkContentType validation failed",
+		"image/en-US/linkMimetypeGroup": "linkMimetypeGroup validation failed",
+		"tags/en-US/size":               "size validation failed",
+		"tags/en-US/in":                 "item 1: in validation failed",
+		"body//unknown":                 "content type post has no field body",
+	}, problems)
+
+	// Image dimensions and link types
+	err = validator.Validate(EntryFields{
+		"title": map[string]interface{}{"en-US": "Hello", "de-DE": "Hallo"},
+		"image": map[string]interface{}{"en-US": link("Asset", "img1")},
+	})
+	assert.Len(t, err.(FieldErrors), 1)
+	assert.Equal(t, "assetImageDimensions", err.(FieldErrors)[0].Validation)
+
+	entry := &Entry{Fields: EntryFields{
+		"title":  map[string]interface{}{"en-US": "Hello", "de-DE": "Hallo"},
+		"author": map[string]interface{}{"en-US": link("Asset", "img1")},
+	}}
+	entry.ContentType = &Link{LinkData: &LinkData{Type: LinkType, LinkType: "ContentType", ID: "post"}}
+
+	err = validator.ValidateEntry(entry)
+	assert.Len(t, err.(FieldErrors), 1)
+	assert.Equal(t, "expected link to Entry, got link to Asset", err.(FieldErrors)[0].Message)
+
+	entry.ContentType.ID = "author"
+	assert.NotNil(t, validator.ValidateEntry(entry), "Entries of other content types should be rejected")
+}
+
+func TestEntryValidatorZeroBounds(t *testing.T) {
+	contentType := &ContentType{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"sys": {"id": "counter"},
+		"fields": [
+			{"id": "count", "type": "Integer", "validations": [{"range": {"min": 0}}]},
+			{"id": "note", "type": "Symbol", "validations": [{"size": {"max": 0}}]}
+		]
+	}`), contentType))
+
+	validator := NewEntryValidator(contentType, validatorLocales())
+	assert.Nil(t, validator.Validate(EntryFields{
+		"count": map[string]interface{}{"en-US": float64(0)},
+		"note":  map[string]interface{}{"en-US": ""},
+	}))
+
+	err := validator.Validate(EntryFields{
+		"count": map[string]interface{}{"en-US": float64(-1)},
+		"note":  map[string]interface{}{"en-US": "x"},
+	})
+	assert.Len(t, err.(FieldErrors), 2, "Zero bounds should be enforced")
+}
@@ -0,0 +1,259 @@
+package management
+
+import (
+	"fmt"
+
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// FetchEnvironments returns all environments of the provided space
+func (c *Client) FetchEnvironments(spaceID string) (environments []*Environment, pagination *Pagination, err error) {
+	if spaceID == "" {
+		return nil, nil, fmt.Errorf("FetchEnvironments failed. Space identifier is not valid!")
+	}
+
+	type environmentsResponse struct {
+		*Pagination
+		Items []*Environment `json:"items"`
+	}
+
+	results := new(environmentsResponse)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/environments", spaceID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, results, contentfulError)
+
+	return results.Items, results.Pagination, handleError(err, contentfulError)
+}
+
+// FetchEnvironment will return an environment for the given space and
+// environment identifier.
+func (c *Client) FetchEnvironment(spaceID string, environmentID string) (environment *Environment, err error) {
+	if spaceID == "" || environmentID == "" {
+		return nil, fmt.Errorf("FetchEnvironment failed. Invalid spaceID or environmentID.")
+	}
+
+	environment = new(Environment)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/environments/%v", spaceID, environmentID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, environment, contentfulError)
+
+	return environment, handleError(err, contentfulError)
+}
+
+// CreateEnvironment will create an environment as a copy of the source
+// environment, or of master if sourceEnvironmentID is empty. If the environment
+// specifies an identifier it is used, otherwise Contentful generates one.
+//
+// Copying the content happens in the background, use Environment.Ready to
+// check whether the environment can be used.
+func (c *Client) CreateEnvironment(spaceID string, environment *Environment, sourceEnvironmentID string) (created *Environment, err error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("CreateEnvironment failed. Space identifier is not valid!")
+	}
+
+	if environment == nil {
+		return nil, fmt.Errorf("CreateEnvironment failed. Environment cannot be nil!")
+	}
+
+	if err = environment.Validate(); err != nil {
+		return
+	}
+
+	body := map[string]string{"name": environment.Name}
+	s := c.sling.New()
+
+	if sourceEnvironmentID != "" {
+		s = s.Set("X-Contentful-Source-Environment", sourceEnvironmentID)
+	}
+
+	if environment.ID != "" {
+		s = s.Put(fmt.Sprintf("spaces/%v/environments/%v", spaceID, environment.ID))
+	} else {
+		s = s.Post(fmt.Sprintf("spaces/%v/environments", spaceID))
+	}
+
+	created = new(Environment)
+	contentfulError := new(Error)
+	req, err := s.BodyJSON(body).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, created, contentfulError)
+
+	return created, handleError(err, contentfulError)
+}
+
+// UpdateEnvironment will update the name of the environment
+func (c *Client) UpdateEnvironment(spaceID string, environment *Environment) (updated *Environment, err error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("UpdateEnvironment failed. Space identifier is not valid!")
+	}
+
+	if environment == nil {
+		return nil, fmt.Errorf("UpdateEnvironment failed. Environment cannot be nil!")
+	}
+
+	if environment.ID == "" {
+		return nil, fmt.Errorf("UpdateEnvironment failed. Environment must specify an identifier!")
+	}
+
+	if err = environment.Validate(); err != nil {
+		return
+	}
+
+	updated = new(Environment)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/environments/%v", spaceID, environment.ID)
+	req, err := c.sling.New().
+		Set("X-Contentful-Version", fmt.Sprintf("%v", environment.Version)).
+		Put(path).
+		BodyJSON(map[string]string{"name": environment.Name}).
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, updated, contentfulError)
+
+	return updated, handleError(err, contentfulError)
+}
+
+// DeleteEnvironment will delete an existing environment along with all of its
+// content. The master environment cannot be deleted.
+func (c *Client) DeleteEnvironment(spaceID string, environmentID string) (err error) {
+	if spaceID == "" || environmentID == "" {
+		return fmt.Errorf("DeleteEnvironment failed. Invalid spaceID or environmentID.")
+	}
+
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/environments/%v", spaceID, environmentID)
+	req, err := c.sling.New().Delete(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, nil, contentfulError)
+
+	return handleError(err, contentfulError)
+}
+
+// FetchEnvironmentAliases returns all environment aliases of the provided space
+func (c *Client) FetchEnvironmentAliases(spaceID string) (aliases []*EnvironmentAlias, pagination *Pagination, err error) {
+	if spaceID == "" {
+		return nil, nil, fmt.Errorf("FetchEnvironmentAliases failed. Space identifier is not valid!")
+	}
+
+	type aliasesResponse struct {
+		*Pagination
+		Items []*EnvironmentAlias `json:"items"`
+	}
+
+	results := new(aliasesResponse)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/environment_aliases", spaceID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, results, contentfulError)
+
+	return results.Items, results.Pagination, handleError(err, contentfulError)
+}
+
+// FetchEnvironmentAlias will return an environment alias for the given space
+// and alias identifier.
+func (c *Client) FetchEnvironmentAlias(spaceID string, aliasID string) (alias *EnvironmentAlias, err error) {
+	if spaceID == "" || aliasID == "" {
+		return nil, fmt.Errorf("FetchEnvironmentAlias failed. Invalid spaceID or aliasID.")
+	}
+
+	alias = new(EnvironmentAlias)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/environment_aliases/%v", spaceID, aliasID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, alias, contentfulError)
+
+	return alias, handleError(err, contentfulError)
+}
+
+// UpdateEnvironmentAlias points the alias at the given environment. Aliases
+// that don't exist yet are created, existing aliases must specify their
+// current version.
+func (c *Client) UpdateEnvironmentAlias(spaceID string, alias *EnvironmentAlias, environmentID string) (updated *EnvironmentAlias, err error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("UpdateEnvironmentAlias failed. Space identifier is not valid!")
+	}
+
+	if alias == nil || alias.ID == "" {
+		return nil, fmt.Errorf("UpdateEnvironmentAlias failed. Alias must specify an identifier!")
+	}
+
+	if environmentID == "" {
+		return nil, fmt.Errorf("UpdateEnvironmentAlias failed. Environment identifier is not valid!")
+	}
+
+	environment := &Environment{System: System{ID: environmentID}}
+	body := map[string]*Link{"environment": environment.Link()}
+
+	updated = new(EnvironmentAlias)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/environment_aliases/%v", spaceID, alias.ID)
+	s := c.sling.New()
+
+	if alias.Version > 0 {
+		s = s.Set("X-Contentful-Version", fmt.Sprintf("%v", alias.Version))
+	}
+
+	req, err := s.Put(path).BodyJSON(body).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, updated, contentfulError)
+
+	return updated, handleError(err, contentfulError)
+}
+
+// DeleteEnvironmentAlias will delete an existing environment alias. The
+// environment it points to is not affected.
+func (c *Client) DeleteEnvironmentAlias(spaceID string, aliasID string) (err error) {
+	if spaceID == "" || aliasID == "" {
+		return fmt.Errorf("DeleteEnvironmentAlias failed. Invalid spaceID or aliasID.")
+	}
+
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/environment_aliases/%v", spaceID, aliasID)
+	req, err := c.sling.New().Delete(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, nil, contentfulError)
+
+	return handleError(err, contentfulError)
+}
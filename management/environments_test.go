@@ -0,0 +1,148 @@
+package management
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/illyabusigin/contentful/models"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestEnvironmentScopedRequests(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	staging := client.InEnvironment("staging")
+
+	assert.Equal(t, "", client.Environment(), "Original client should not be modified")
+	assert.Equal(t, "staging", staging.Environment())
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	staging.sling = staging.sling.New().Doer(doer)
+
+	staging.FetchEntry("space123", "entry123")
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/environments/staging/entries/entry123", doer.request.URL.String())
+
+	staging.FetchAsset("space123", "asset123")
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/environments/staging/assets/asset123", doer.request.URL.String())
+
+	staging.FetchContentType("space123", "ct123")
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/environments/staging/content_types/ct123", doer.request.URL.String())
+
+	staging.FetchAllLocales("space123")
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/environments/staging/locales", doer.request.URL.String())
+}
+
+func TestCreateEnvironmentRequest(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	_, err := client.CreateEnvironment("space123", &Environment{}, "")
+	assert.NotNil(t, err, "Environment without a name should not be created")
+	assert.Nil(t, doer.request)
+
+	_, err = client.CreateEnvironment("space123", &Environment{Name: "Staging"}, "")
+	req := doer.request
+
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/environments", req.URL.String())
+
+	environment := &Environment{Name: "Staging"}
+	environment.ID = "staging"
+	_, err = client.CreateEnvironment("space123", environment, "master")
+	req = doer.request
+
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/environments/staging", req.URL.String())
+	assert.Equal(t, "master", req.Header.Get("X-Contentful-Source-Environment"))
+
+	requestJSON, _ := ioutil.ReadAll(req.Body)
+	assert.JSONEq(t, `{"name":"Staging"}`, string(requestJSON))
+}
+
+func TestEnvironmentValidationFailures(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	environment := &Environment{System: System{ID: "staging"}, Name: "Staging"}
+
+	_, err := client.CreateEnvironment("", environment, "")
+	assert.NotNil(t, err)
+	assert.Equal(t, "CreateEnvironment failed. Space identifier is not valid!", err.Error())
+
+	_, err = client.UpdateEnvironment("", environment)
+	assert.NotNil(t, err)
+	assert.Equal(t, "UpdateEnvironment failed. Space identifier is not valid!", err.Error())
+
+	_, err = client.UpdateEnvironment("space123", &Environment{Name: "Staging"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "UpdateEnvironment failed. Environment must specify an identifier!", err.Error())
+
+	_, err = client.UpdateEnvironmentAlias("", &EnvironmentAlias{System: System{ID: "master"}}, "staging")
+	assert.NotNil(t, err)
+	assert.Equal(t, "UpdateEnvironmentAlias failed. Space identifier is not valid!", err.Error())
+
+	assert.Nil(t, doer.request, "No request should be performed")
+}
+
+func TestDeleteEnvironmentRequest(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	err := client.DeleteEnvironment("space123", "staging")
+	req := doer.request
+
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, http.MethodDelete, req.Method)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/environments/staging", req.URL.String())
+}
+
+func TestUpdateEnvironmentAliasRequest(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	alias := &EnvironmentAlias{}
+	alias.ID = "master"
+	alias.Version = 3
+
+	_, err := client.UpdateEnvironmentAlias("space123", alias, "release-2")
+	req := doer.request
+
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/environment_aliases/master", req.URL.String())
+	assert.Equal(t, "3", req.Header.Get("X-Contentful-Version"))
+
+	requestJSON, _ := ioutil.ReadAll(req.Body)
+	assert.JSONEq(t, `{"environment":{"sys":{"type":"Link","linkType":"Environment","id":"release-2"}}}`, string(requestJSON))
+}
+
+func TestEnvironmentReady(t *testing.T) {
+	environment := &Environment{Name: "Staging"}
+	assert.False(t, environment.Ready())
+
+	environment.Status = &Link{LinkData: &LinkData{Type: LinkType, LinkType: "Status", ID: "queued"}}
+	assert.False(t, environment.Ready())
+
+	environment.Status.ID = EnvironmentReady
+	assert.True(t, environment.Ready())
+}
@@ -0,0 +1,446 @@
+package management
+
+import (
+	"github.com/illyabusigin/contentful/internal/pagination"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// EntryIterator lazily iterates over all entries matching a query, fetching
+// one page at a time.
+//
+//	it := client.IterateEntries(spaceID, NewQuery().ContentType("post"))
+//	for it.Next() {
+//		entry := it.Entry()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type EntryIterator struct {
+	client  *Client
+	spaceID string
+	query   *Query
+
+	pager    pagination.Pager
+	entries  []*Entry
+	includes *Includes
+	seen     map[string]bool
+	errors   []error
+}
+
+// IterateEntries returns an iterator over all entries matching the query
+func (c *Client) IterateEntries(spaceID string, query *Query) *EntryIterator {
+	return &EntryIterator{
+		client:  c,
+		spaceID: spaceID,
+		query:   query,
+		pager:   pagination.Pager{PageSize: c.pageSize},
+		includes: &Includes{
+			Entries: []*Entry{},
+			Assets:  []*Asset{},
+		},
+		seen:   map[string]bool{},
+		errors: []error{},
+	}
+}
+
+// Next advances the iterator and reports whether an entry is available
+func (it *EntryIterator) Next() bool {
+	return it.pager.Next(func(limit int, offset int) (int, *Pagination, error) {
+		result := it.client.SearchEntries(it.spaceID, it.query, limit, offset)
+		for _, err := range result.Errors {
+			if _, ok := err.(*ContentError); !ok {
+				return 0, nil, err
+			}
+
+			it.errors = append(it.errors, err)
+		}
+
+		it.entries = result.Entries
+		it.mergeIncludes(result.Includes)
+
+		return len(result.Entries), result.Pagination, nil
+	})
+}
+
+// Entry returns the current entry
+func (it *EntryIterator) Entry() *Entry {
+	if it.pager.Index() >= len(it.entries) {
+		return nil
+	}
+
+	return it.entries[it.pager.Index()]
+}
+
+// Includes returns the linked entries and assets of all pages fetched so far
+func (it *EntryIterator) Includes() *Includes {
+	return it.includes
+}
+
+// Errors returns the ContentErrors reported for all pages fetched so far
+func (it *EntryIterator) Errors() []error {
+	return it.errors
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *EntryIterator) Err() error {
+	return it.pager.Err()
+}
+
+// Chan returns a channel that receives every entry. The channel is closed when
+// the iteration finishes, fails or done is closed; check Err afterwards.
+func (it *EntryIterator) Chan(done <-chan struct{}) <-chan *Entry {
+	ch := make(chan *Entry)
+
+	go func() {
+		defer close(ch)
+
+		for it.Next() {
+			select {
+			case ch <- it.Entry():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+func (it *EntryIterator) mergeIncludes(includes *Includes) {
+	if includes == nil {
+		return
+	}
+
+	for _, entry := range includes.Entries {
+		if !it.seen["Entry:"+entry.ID] {
+			it.seen["Entry:"+entry.ID] = true
+			it.includes.Entries = append(it.includes.Entries, entry)
+		}
+	}
+
+	for _, asset := range includes.Assets {
+		if !it.seen["Asset:"+asset.ID] {
+			it.seen["Asset:"+asset.ID] = true
+			it.includes.Assets = append(it.includes.Assets, asset)
+		}
+	}
+}
+
+// AssetIterator lazily iterates over all assets matching a query, fetching one
+// page at a time.
+type AssetIterator struct {
+	client    *Client
+	spaceID   string
+	published bool
+	query     *Query
+
+	pager  pagination.Pager
+	assets []*Asset
+}
+
+// IterateAssets returns an iterator over all assets matching the query. You
+// can toggle the published flag to only iterate over published assets.
+func (c *Client) IterateAssets(spaceID string, published bool, query *Query) *AssetIterator {
+	return &AssetIterator{
+		client:    c,
+		spaceID:   spaceID,
+		published: published,
+		query:     query,
+		pager:     pagination.Pager{PageSize: c.pageSize},
+	}
+}
+
+// Next advances the iterator and reports whether an asset is available
+func (it *AssetIterator) Next() bool {
+	return it.pager.Next(func(limit int, offset int) (int, *Pagination, error) {
+		assets, pagination, err := it.client.SearchAssets(it.spaceID, it.published, it.query, limit, offset)
+		it.assets = assets
+
+		return len(assets), pagination, err
+	})
+}
+
+// Asset returns the current asset
+func (it *AssetIterator) Asset() *Asset {
+	if it.pager.Index() >= len(it.assets) {
+		return nil
+	}
+
+	return it.assets[it.pager.Index()]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *AssetIterator) Err() error {
+	return it.pager.Err()
+}
+
+// Chan returns a channel that receives every asset. The channel is closed when
+// the iteration finishes, fails or done is closed; check Err afterwards.
+func (it *AssetIterator) Chan(done <-chan struct{}) <-chan *Asset {
+	ch := make(chan *Asset)
+
+	go func() {
+		defer close(ch)
+
+		for it.Next() {
+			select {
+			case ch <- it.Asset():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// ContentTypeIterator lazily iterates over all content types of a space
+type ContentTypeIterator struct {
+	client    *Client
+	spaceID   string
+	published bool
+
+	pager        pagination.Pager
+	contentTypes []*ContentType
+}
+
+// IterateContentTypes returns an iterator over all content types of the space.
+// You can toggle the published flag to only iterate over activated content
+// types.
+func (c *Client) IterateContentTypes(spaceID string, published bool) *ContentTypeIterator {
+	return &ContentTypeIterator{
+		client:    c,
+		spaceID:   spaceID,
+		published: published,
+		pager:     pagination.Pager{PageSize: 100},
+	}
+}
+
+// Next advances the iterator and reports whether a content type is available
+func (it *ContentTypeIterator) Next() bool {
+	return it.pager.Next(func(limit int, offset int) (int, *Pagination, error) {
+		contentTypes, pagination, err := it.client.FetchContentTypes(it.spaceID, it.published, limit, offset)
+		it.contentTypes = contentTypes
+
+		return len(contentTypes), pagination, err
+	})
+}
+
+// ContentType returns the current content type
+func (it *ContentTypeIterator) ContentType() *ContentType {
+	if it.pager.Index() >= len(it.contentTypes) {
+		return nil
+	}
+
+	return it.contentTypes[it.pager.Index()]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ContentTypeIterator) Err() error {
+	return it.pager.Err()
+}
+
+// Chan returns a channel that receives every content type. The channel is
+// closed when the iteration finishes, fails or done is closed; check Err
+// afterwards.
+func (it *ContentTypeIterator) Chan(done <-chan struct{}) <-chan *ContentType {
+	ch := make(chan *ContentType)
+
+	go func() {
+		defer close(ch)
+
+		for it.Next() {
+			select {
+			case ch <- it.ContentType():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// APIKeyIterator lazily iterates over all Content Delivery API keys of a space
+type APIKeyIterator struct {
+	client  *Client
+	spaceID string
+
+	pager pagination.Pager
+	keys  []*APIKey
+}
+
+// IterateAPIKeys returns an iterator over all Content Delivery API keys of a space
+func (c *Client) IterateAPIKeys(spaceID string) *APIKeyIterator {
+	return &APIKeyIterator{
+		client:  c,
+		spaceID: spaceID,
+		pager:   pagination.Pager{PageSize: 100},
+	}
+}
+
+// Next advances the iterator and reports whether an API key is available
+func (it *APIKeyIterator) Next() bool {
+	return it.pager.Next(func(limit int, offset int) (int, *Pagination, error) {
+		keys, pagination, err := it.client.FetchContentDeliveryAPIKeys(it.spaceID, limit, offset)
+		it.keys = keys
+
+		return len(keys), pagination, err
+	})
+}
+
+// APIKey returns the current API key
+func (it *APIKeyIterator) APIKey() *APIKey {
+	if it.pager.Index() >= len(it.keys) {
+		return nil
+	}
+
+	return it.keys[it.pager.Index()]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *APIKeyIterator) Err() error {
+	return it.pager.Err()
+}
+
+// Chan returns a channel that receives every API key. The channel is closed
+// when the iteration finishes, fails or done is closed; check Err afterwards.
+func (it *APIKeyIterator) Chan(done <-chan struct{}) <-chan *APIKey {
+	ch := make(chan *APIKey)
+
+	go func() {
+		defer close(ch)
+
+		for it.Next() {
+			select {
+			case ch <- it.APIKey():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// LocaleIterator lazily iterates over all locales of a space
+type LocaleIterator struct {
+	client  *Client
+	spaceID string
+
+	pager   pagination.Pager
+	locales []*Locale
+}
+
+// IterateLocales returns an iterator over all locales of a space
+func (c *Client) IterateLocales(spaceID string) *LocaleIterator {
+	return &LocaleIterator{
+		client:  c,
+		spaceID: spaceID,
+		pager:   pagination.Pager{PageSize: 100},
+	}
+}
+
+// Next advances the iterator and reports whether a locale is available
+func (it *LocaleIterator) Next() bool {
+	return it.pager.Next(func(limit int, offset int) (int, *Pagination, error) {
+		locales, pagination, err := it.client.FetchLocales(it.spaceID, limit, offset)
+		it.locales = locales
+
+		return len(locales), pagination, err
+	})
+}
+
+// Locale returns the current locale
+func (it *LocaleIterator) Locale() *Locale {
+	if it.pager.Index() >= len(it.locales) {
+		return nil
+	}
+
+	return it.locales[it.pager.Index()]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *LocaleIterator) Err() error {
+	return it.pager.Err()
+}
+
+// Chan returns a channel that receives every locale. The channel is closed
+// when the iteration finishes, fails or done is closed; check Err afterwards.
+func (it *LocaleIterator) Chan(done <-chan struct{}) <-chan *Locale {
+	ch := make(chan *Locale)
+
+	go func() {
+		defer close(ch)
+
+		for it.Next() {
+			select {
+			case ch <- it.Locale():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// SpaceIterator lazily iterates over all spaces of the account
+type SpaceIterator struct {
+	client *Client
+
+	pager  pagination.Pager
+	spaces []*Space
+}
+
+// IterateSpaces returns an iterator over all spaces of the account
+func (c *Client) IterateSpaces() *SpaceIterator {
+	return &SpaceIterator{
+		client: c,
+		pager:  pagination.Pager{PageSize: 100},
+	}
+}
+
+// Next advances the iterator and reports whether a space is available
+func (it *SpaceIterator) Next() bool {
+	return it.pager.Next(func(limit int, offset int) (int, *Pagination, error) {
+		spaces, pagination, err := it.client.FetchSpaces(limit, offset)
+		it.spaces = spaces
+
+		return len(spaces), pagination, err
+	})
+}
+
+// Space returns the current space
+func (it *SpaceIterator) Space() *Space {
+	if it.pager.Index() >= len(it.spaces) {
+		return nil
+	}
+
+	return it.spaces[it.pager.Index()]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *SpaceIterator) Err() error {
+	return it.pager.Err()
+}
+
+// Chan returns a channel that receives every space. The channel is closed
+// when the iteration finishes, fails or done is closed; check Err afterwards.
+func (it *SpaceIterator) Chan(done <-chan struct{}) <-chan *Space {
+	ch := make(chan *Space)
+
+	go func() {
+		defer close(ch)
+
+		for it.Next() {
+			select {
+			case ch <- it.Space():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,98 @@
+package management
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/illyabusigin/contentful/models"
+	assert "github.com/stretchr/testify/require"
+)
+
+// sequenceDoer returns a new response for every request and fails with
+// errIntercept once all bodies are used
+type sequenceDoer struct {
+	requests []*http.Request
+	bodies   []string
+}
+
+func (d *sequenceDoer) Do(req *http.Request) (*http.Response, error) {
+	d.requests = append(d.requests, req)
+	if len(d.bodies) == 0 {
+		return nil, errIntercept
+	}
+
+	body := d.bodies[0]
+	d.bodies = d.bodies[1:]
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/vnd.contentful.management.v1+json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestEntryIterator(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	doer := &sequenceDoer{bodies: []string{
+		`{"total": 3, "skip": 0, "limit": 2, "items": [{"sys": {"id": "1"}}, {"sys": {"id": "2"}}], "includes": {"Asset": [{"sys": {"id": "a"}}]}}`,
+		`{"total": 3, "skip": 2, "limit": 2, "items": [{"sys": {"id": "3"}}], "includes": {"Asset": [{"sys": {"id": "a"}}, {"sys": {"id": "b"}}]}}`,
+	}}
+	client.sling = client.sling.New().Doer(doer)
+
+	it := client.IterateEntries("space123", NewQuery().ContentType("post"))
+	it.pager.PageSize = 2
+
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, it.Entry().ID)
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Len(t, doer.requests, 2)
+	assert.Equal(t, "0", doer.requests[0].URL.Query().Get("skip"))
+	assert.Equal(t, "2", doer.requests[1].URL.Query().Get("skip"))
+	assert.Equal(t, "post", doer.requests[1].URL.Query().Get("content_type"))
+	assert.Len(t, it.Includes().Assets, 2, "Includes should be merged across pages")
+	assert.False(t, it.Next())
+}
+
+func TestEntryIteratorError(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	it := client.IterateEntries("space123", nil)
+	assert.False(t, it.Next())
+	assert.Equal(t, errIntercept, it.Err())
+	assert.Nil(t, it.Entry())
+}
+
+func TestSpaceIteratorChan(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+	assert.NotNil(t, client, "Client should not be nil")
+
+	doer := &sequenceDoer{bodies: []string{
+		`{"total": 2, "skip": 0, "limit": 100, "items": [{"sys": {"id": "1"}, "name": "One"}, {"sys": {"id": "2"}, "name": "Two"}]}`,
+	}}
+	client.sling = client.sling.New().Doer(doer)
+
+	it := client.IterateSpaces()
+	names := []string{}
+	for space := range it.Chan(nil) {
+		names = append(names, space.Name)
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"One", "Two"}, names)
+	assert.Equal(t, "https://api.contentful.com/spaces?limit=100&skip=0", doer.requests[0].URL.String())
+}
@@ -8,8 +8,6 @@ import (
 
 // FetchAllLocales returns all locales associated with the provided space identifier
 func (c *Client) FetchAllLocales(spaceID string) (locales []*Locale, pagination *Pagination, err error) {
-	c.rl.Wait()
-
 	type localesResponse struct {
 		*Pagination
 		Sys struct {
@@ -20,8 +18,56 @@ func (c *Client) FetchAllLocales(spaceID string) (locales []*Locale, pagination
 
 	results := new(localesResponse)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/locales", spaceID)
-	_, err = c.sling.New().Get(path).Receive(results, contentfulError)
+	path := c.spacePath(spaceID, "locales")
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, results, contentfulError)
+
+	return results.Items, results.Pagination, handleError(err, contentfulError)
+}
+
+// FetchLocales returns a page of locales associated with the provided space
+// identifier.
+func (c *Client) FetchLocales(spaceID string, limit int, offset int) (locales []*Locale, pagination *Pagination, err error) {
+	if spaceID == "" {
+		return nil, nil, fmt.Errorf("FetchLocales failed. Space identifier is not valid!")
+	}
+
+	if limit <= 0 {
+		return nil, nil, fmt.Errorf("FetchLocales failed. Limit must be greater than 0")
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	type localesResponse struct {
+		*Pagination
+		Items []*Locale `json:"items"`
+	}
+
+	results := new(localesResponse)
+	contentfulError := new(Error)
+	path := c.spacePath(spaceID, "locales")
+	req, err := c.sling.New().
+		Get(path).
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	// Add query parameters
+	q := req.URL.Query()
+	q.Set("skip", fmt.Sprintf("%v", offset))
+	q.Set("limit", fmt.Sprintf("%v", limit))
+	req.URL.RawQuery = q.Encode()
+
+	_, err = c.do(req, results, contentfulError)
 
 	return results.Items, results.Pagination, handleError(err, contentfulError)
 }
@@ -36,27 +82,35 @@ func (c *Client) CreateLocale(spaceID string, locale *Locale) (created *Locale,
 		return
 	}
 
-	c.rl.Wait()
-
 	// Default cannot be set via the API, set to false so it will not appear in the request body
 	locale.Default = false
 
 	created = new(Locale)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/locales", spaceID)
-	_, err = c.sling.New().Post(path).BodyJSON(locale).Receive(created, contentfulError)
+	path := c.spacePath(spaceID, "locales")
+	req, err := c.sling.New().Post(path).BodyJSON(locale).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, created, contentfulError)
 
 	return created, handleError(err, contentfulError)
 }
 
 // FetchLocale will return a locale for the given space and locale identifier.
 func (c *Client) FetchLocale(spaceID string, localeID string) (locale *Locale, err error) {
-	c.rl.Wait()
-
 	locale = new(Locale)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/locales/%v", spaceID, localeID)
-	_, err = c.sling.New().Get(path).Receive(locale, contentfulError)
+	path := c.spacePath(spaceID, "locales/%v", localeID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, locale, contentfulError)
 
 	return locale, handleError(err, contentfulError)
 }
@@ -74,16 +128,20 @@ func (c *Client) UpdateLocale(locale *Locale) (updated *Locale, err error) {
 		return
 	}
 
-	c.rl.Wait()
-
 	updated = new(Locale)
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/locales/%v", locale.Space.ID, locale.System.ID)
-	_, err = c.sling.New().
+	path := c.spacePath(locale.Space.ID, "locales/%v", locale.System.ID)
+	req, err := c.sling.New().
 		Set("X-Contentful-Version", fmt.Sprintf("%v", locale.System.Version)).
 		Put(path).
 		BodyJSON(locale).
-		Receive(updated, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, updated, contentfulError)
 
 	return updated, handleError(err, contentfulError)
 }
@@ -93,11 +151,15 @@ func (c *Client) UpdateLocale(locale *Locale) (updated *Locale, err error) {
 // was stored for that specific locale gets deleted and cannot be
 // recreated by creating the same locale again.
 func (c *Client) DeleteLocale(spaceID string, localeID string) (err error) {
-	c.rl.Wait()
-
 	contentfulError := new(Error)
-	path := fmt.Sprintf("spaces/%v/locales/%v", spaceID, localeID)
-	_, err = c.sling.New().Delete(path).Receive(nil, contentfulError)
+	path := c.spacePath(spaceID, "locales/%v", localeID)
+	req, err := c.sling.New().Delete(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, nil, contentfulError)
 
 	return handleError(err, contentfulError)
 }
@@ -0,0 +1,170 @@
+package management
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/illyabusigin/contentful/transport"
+)
+
+// Option configures a Client created with New
+type Option func(*options)
+
+type options struct {
+	baseURL      string
+	uploadURL    string
+	version      string
+	doer         Doer
+	middleware   []transport.Middleware
+	userAgent    string
+	logger       transport.StructuredLogger
+	retryPolicy  *transport.RetryPolicy
+	environment  string
+	pageSize     int
+	rateLimit    int
+	rateInterval time.Duration
+}
+
+func defaultOptions() *options {
+	return &options{
+		baseURL:      baseURL + "/",
+		uploadURL:    uploadBaseURL + "/",
+		version:      "v1",
+		doer:         http.DefaultClient,
+		retryPolicy:  transport.DefaultRetryPolicy(),
+		pageSize:     PaginationSizeLimit,
+		rateLimit:    10,
+		rateInterval: time.Second,
+	}
+}
+
+// WithBaseURL points the client at a different host, for example a regional
+// endpoint or a local test server.
+func WithBaseURL(url string) Option {
+	return func(o *options) {
+		if !strings.HasSuffix(url, "/") {
+			url += "/"
+		}
+
+		o.baseURL = url
+	}
+}
+
+// WithUploadBaseURL points uploads at a different host than
+// https://upload.contentful.com.
+func WithUploadBaseURL(url string) Option {
+	return func(o *options) {
+		if !strings.HasSuffix(url, "/") {
+			url += "/"
+		}
+
+		o.uploadURL = url
+	}
+}
+
+// WithVersion sets the API version sent in the Content-Type header. Defaults
+// to "v1".
+func WithVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to perform requests. Defaults to
+// http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		if httpClient != nil {
+			o.doer = httpClient
+		}
+	}
+}
+
+// WithDoer sets the Doer used to perform requests, such as a custom
+// *http.Client wrapper or a stack of middleware. Defaults to
+// http.DefaultClient.
+func WithDoer(doer Doer) Option {
+	return func(o *options) {
+		if doer != nil {
+			o.doer = doer
+		}
+	}
+}
+
+// WithMiddleware wraps every request performed by the client with the
+// middlewares, see transport.Chain. Retries are performed within the
+// middleware.
+//
+//	client := New(token, WithMiddleware(
+//		transport.RequestID(nil),
+//		transport.Metrics(observe)))
+func WithMiddleware(middlewares ...transport.Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, middlewares...)
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithLogger logs every request performed by the client, such as with a
+// *log.Logger
+func WithLogger(logger transport.Logger) Option {
+	return func(o *options) {
+		if logger == nil {
+			o.logger = nil
+			return
+		}
+
+		o.logger = transport.PrintfLogger(logger)
+	}
+}
+
+// WithStructuredLogger logs every request performed by the client at debug
+// level along with its status, duration, request ID and rate limit headers
+func WithStructuredLogger(logger transport.StructuredLogger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithRetryPolicy configures how failed requests are retried. A nil policy
+// disables retries.
+func WithRetryPolicy(policy *transport.RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithRateLimit limits the client to the given number of requests per
+// interval. Defaults to 10 requests per second, a limit of 0 disables client
+// side rate limiting.
+func WithRateLimit(limit int, interval time.Duration) Option {
+	return func(o *options) {
+		o.rateLimit = limit
+		o.rateInterval = interval
+	}
+}
+
+// WithEnvironment scopes entries, assets, content types and locales to the
+// given environment instead of master.
+func WithEnvironment(environmentID string) Option {
+	return func(o *options) {
+		o.environment = environmentID
+	}
+}
+
+// WithPageSize sets the maximum number of items requested per page. Defaults
+// to PaginationSizeLimit.
+func WithPageSize(pageSize int) Option {
+	return func(o *options) {
+		if pageSize > 0 {
+			o.pageSize = pageSize
+		}
+	}
+}
@@ -8,8 +8,6 @@ import (
 
 // FetchAllSpaces returns all spaces associated with the account
 func (c *Client) FetchAllSpaces() (spaces []*Space, pagination *Pagination, err error) {
-	c.rl.Wait()
-
 	type spacesResponse struct {
 		*Pagination
 		Sys struct {
@@ -21,9 +19,51 @@ func (c *Client) FetchAllSpaces() (spaces []*Space, pagination *Pagination, err
 	results := new(spacesResponse)
 	contentfulError := new(Error)
 	path := fmt.Sprintf("spaces")
-	_, err = c.sling.New().Get(path).Receive(results, contentfulError)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, results, contentfulError)
+
+	return results.Items, results.Pagination, handleError(err, contentfulError)
+}
+
+// FetchSpaces returns a page of the spaces associated with the account
+func (c *Client) FetchSpaces(limit int, offset int) (spaces []*Space, pagination *Pagination, err error) {
+	if limit <= 0 {
+		return nil, nil, fmt.Errorf("FetchSpaces failed. Limit must be greater than 0")
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	type spacesResponse struct {
+		*Pagination
+		Items []*Space `json:"items"`
+	}
+
+	results := new(spacesResponse)
+	contentfulError := new(Error)
+	req, err := c.sling.New().
+		Get("spaces").
+		Request()
+
+	if err != nil {
+		return
+	}
 
-	return spaces, results.Pagination, handleError(err, contentfulError)
+	// Add query parameters
+	q := req.URL.Query()
+	q.Set("skip", fmt.Sprintf("%v", offset))
+	q.Set("limit", fmt.Sprintf("%v", limit))
+	req.URL.RawQuery = q.Encode()
+
+	_, err = c.do(req, results, contentfulError)
+
+	return results.Items, results.Pagination, handleError(err, contentfulError)
 }
 
 // CreateSpace will create a space with the provided name. It's important to
@@ -37,24 +77,32 @@ func (c *Client) CreateSpace(space *Space) (created *Space, err error) {
 		return
 	}
 
-	c.rl.Wait()
-
 	created = new(Space)
 	contentfulError := new(Error)
 	path := fmt.Sprintf("spaces")
-	_, err = c.sling.New().Post(path).BodyJSON(space).Receive(created, contentfulError)
+	req, err := c.sling.New().Post(path).BodyJSON(space).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, created, contentfulError)
 
 	return created, handleError(err, contentfulError)
 }
 
 // FetchSpace will return a space for the given identifier.
 func (c *Client) FetchSpace(identifier string) (space *Space, err error) {
-	c.rl.Wait()
-
 	space = new(Space)
 	contentfulError := new(Error)
 	path := fmt.Sprintf("spaces/%v", identifier)
-	_, err = c.sling.New().Get(path).Receive(space, contentfulError)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, space, contentfulError)
 
 	return space, handleError(err, contentfulError)
 }
@@ -65,16 +113,20 @@ func (c *Client) UpdateSpace(space *Space) (updated *Space, err error) {
 		return nil, fmt.Errorf("Unable to update. Space argument was nil!")
 	}
 
-	c.rl.Wait()
-
 	updated = new(Space)
 	contentfulError := new(Error)
 	path := fmt.Sprintf("spaces/%v", space.System.ID)
-	_, err = c.sling.New().
+	req, err := c.sling.New().
 		Set("X-Contentful-Version", fmt.Sprintf("%v", space.System.Version)).
 		Put(path).
 		BodyJSON(space).
-		Receive(updated, contentfulError)
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, updated, contentfulError)
 
 	return updated, handleError(err, contentfulError)
 }
@@ -83,11 +135,15 @@ func (c *Client) UpdateSpace(space *Space) (updated *Space, err error) {
 // Note that deleting a space will remove its entire content, including all content
 // types, entries and assets. Be careful as this action can not be undone.
 func (c *Client) DeleteSpace(identifier string) (err error) {
-	c.rl.Wait()
-
 	contentfulError := new(Error)
 	path := fmt.Sprintf("spaces/%v", identifier)
-	_, err = c.sling.New().Delete(path).Receive(nil, contentfulError)
+	req, err := c.sling.New().Delete(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, nil, contentfulError)
 
 	return handleError(err, contentfulError)
 }
@@ -0,0 +1,112 @@
+package management
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// sniffLen is the number of bytes used to detect the MIME type of uploads
+const sniffLen = 512
+
+// Upload streams the content of r to the Upload API. size is the number of
+// bytes r will return, pass -1 if it is unknown. The content is not buffered
+// in memory, so large files can be uploaded as long as r is not read
+// elsewhere.
+//
+// The MIME type of the upload is detected from its first 512 bytes. Use the
+// returned upload to create an asset:
+//
+//	upload, err := client.Upload(spaceID, f, info.Size())
+//	...
+//	file := &File{SpaceID: spaceID, Fields: FileFields{
+//		Title: map[string]string{"en-US": "Logo"},
+//		File:  map[string]FileData{"en-US": upload.FileData("logo.png")},
+//	}}
+//	asset, err := client.CreateAsset(file)
+func (c *Client) Upload(spaceID string, r io.Reader, size int64) (upload *Upload, err error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("Upload failed. Space identifier is not valid!")
+	}
+
+	if r == nil {
+		return nil, fmt.Errorf("Upload failed. Reader cannot be nil!")
+	}
+
+	body := bufio.NewReaderSize(r, sniffLen)
+	head, err := body.Peek(sniffLen)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, fmt.Errorf("Upload failed. Unable to read content: %v", err)
+	}
+
+	mimeType := http.DetectContentType(head)
+
+	upload = new(Upload)
+	contentfulError := new(Error)
+	path := c.uploadURL + c.spacePath(spaceID, "uploads")
+	req, err := c.sling.New().
+		Post(path).
+		Set("Content-Type", "application/octet-stream").
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	// The body is set on the request directly so it is streamed instead of
+	// being encoded by sling
+	req.Body = ioutil.NopCloser(body)
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+
+	_, err = c.do(req, upload, contentfulError)
+	upload.MIMEType = mimeType
+
+	return upload, handleError(err, contentfulError)
+}
+
+// FetchUpload will return an upload for the given space and upload identifier
+func (c *Client) FetchUpload(spaceID string, uploadID string) (upload *Upload, err error) {
+	if spaceID == "" || uploadID == "" {
+		return nil, fmt.Errorf("FetchUpload failed. Invalid spaceID or uploadID.")
+	}
+
+	upload = new(Upload)
+	contentfulError := new(Error)
+	path := c.uploadURL + c.spacePath(spaceID, "uploads/%v", uploadID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, upload, contentfulError)
+
+	return upload, handleError(err, contentfulError)
+}
+
+// DeleteUpload will delete an upload. Assets that were already processed
+// from the upload are not affected.
+func (c *Client) DeleteUpload(spaceID string, uploadID string) (err error) {
+	if spaceID == "" || uploadID == "" {
+		return fmt.Errorf("DeleteUpload failed. Invalid spaceID or uploadID.")
+	}
+
+	contentfulError := new(Error)
+	path := c.uploadURL + c.spacePath(spaceID, "uploads/%v", uploadID)
+	req, err := c.sling.New().Delete(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, nil, contentfulError)
+
+	return handleError(err, contentfulError)
+}
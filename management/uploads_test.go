@@ -0,0 +1,64 @@
+package management
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/illyabusigin/contentful/models"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestUploadRequest(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.response = &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"sys":{"id":"upload123","type":"Upload"}}`)),
+	}
+	client.sling = client.sling.New().Doer(doer)
+
+	content := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 2048)...)
+	upload, err := client.InEnvironment("staging").Upload("space123", bytes.NewReader(content), int64(len(content)))
+	req := doer.request
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "https://upload.contentful.com/spaces/space123/environments/staging/uploads", req.URL.String())
+	assert.Equal(t, "application/octet-stream", req.Header.Get("Content-Type"))
+	assert.Equal(t, "Bearer access_token", req.Header.Get("Authorization"))
+	assert.Equal(t, int64(len(content)), req.ContentLength)
+
+	requestBody, _ := ioutil.ReadAll(req.Body)
+	assert.Equal(t, content, requestBody, "Sniffed bytes should still be sent")
+
+	assert.Equal(t, "upload123", upload.ID)
+	assert.Equal(t, "image/png", upload.MIMEType)
+
+	data := upload.FileData("logo.png")
+	assert.Equal(t, "Upload", data.UploadFrom.LinkType)
+	assert.Equal(t, "upload123", data.UploadFrom.ID)
+}
+
+func TestFileUploadFromValidation(t *testing.T) {
+	upload := &Upload{MIMEType: "image/png"}
+	upload.ID = "upload123"
+
+	file := &File{
+		SpaceID: "space123",
+		Fields: FileFields{
+			Title: map[string]string{"en-US": "Logo"},
+			File:  map[string]FileData{"en-US": upload.FileData("logo.png")},
+		},
+	}
+	assert.Nil(t, file.Validate())
+
+	data := file.Fields.File["en-US"]
+	data.URL = "https://example.com/logo.png"
+	file.Fields.File["en-US"] = data
+	assert.NotNil(t, file.Validate(), "URL and UploadFrom should be mutually exclusive")
+}
@@ -0,0 +1,201 @@
+package management
+
+import (
+	"fmt"
+
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// FetchWebhooks returns all webhook definitions of the provided space
+func (c *Client) FetchWebhooks(spaceID string) (webhooks []*Webhook, pagination *Pagination, err error) {
+	if spaceID == "" {
+		return nil, nil, fmt.Errorf("FetchWebhooks failed. Space identifier is not valid!")
+	}
+
+	type webhooksResponse struct {
+		*Pagination
+		Items []*Webhook `json:"items"`
+	}
+
+	results := new(webhooksResponse)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/webhook_definitions", spaceID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, results, contentfulError)
+
+	return results.Items, results.Pagination, handleError(err, contentfulError)
+}
+
+// FetchWebhook will return a webhook definition for the given space and
+// webhook identifier.
+func (c *Client) FetchWebhook(spaceID string, webhookID string) (webhook *Webhook, err error) {
+	if spaceID == "" || webhookID == "" {
+		return nil, fmt.Errorf("FetchWebhook failed. Invalid spaceID or webhookID.")
+	}
+
+	webhook = new(Webhook)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/webhook_definitions/%v", spaceID, webhookID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, webhook, contentfulError)
+
+	return webhook, handleError(err, contentfulError)
+}
+
+// CreateWebhook will create a webhook definition in the provided space
+func (c *Client) CreateWebhook(spaceID string, webhook *Webhook) (created *Webhook, err error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("CreateWebhook failed. Space identifier is not valid!")
+	}
+
+	if webhook == nil {
+		return nil, fmt.Errorf("CreateWebhook failed. Webhook cannot be nil!")
+	}
+
+	if err = webhook.Validate(); err != nil {
+		return
+	}
+
+	created = new(Webhook)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/webhook_definitions", spaceID)
+	req, err := c.sling.New().Post(path).BodyJSON(webhook).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, created, contentfulError)
+
+	return created, handleError(err, contentfulError)
+}
+
+// UpdateWebhook will update the webhook definition. Omitting the basic auth
+// password removes it from the webhook.
+func (c *Client) UpdateWebhook(spaceID string, webhook *Webhook) (updated *Webhook, err error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("UpdateWebhook failed. Space identifier is not valid!")
+	}
+
+	if webhook == nil {
+		return nil, fmt.Errorf("UpdateWebhook failed. Webhook cannot be nil!")
+	}
+
+	if err = webhook.Validate(); err != nil {
+		return
+	}
+
+	updated = new(Webhook)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/webhook_definitions/%v", spaceID, webhook.ID)
+	req, err := c.sling.New().
+		Set("X-Contentful-Version", fmt.Sprintf("%v", webhook.Version)).
+		Put(path).
+		BodyJSON(webhook).
+		Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, updated, contentfulError)
+
+	return updated, handleError(err, contentfulError)
+}
+
+// DeleteWebhook will delete an existing webhook definition
+func (c *Client) DeleteWebhook(spaceID string, webhookID string) (err error) {
+	if spaceID == "" || webhookID == "" {
+		return fmt.Errorf("DeleteWebhook failed. Invalid spaceID or webhookID.")
+	}
+
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/webhook_definitions/%v", spaceID, webhookID)
+	req, err := c.sling.New().Delete(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, nil, contentfulError)
+
+	return handleError(err, contentfulError)
+}
+
+// FetchWebhookCalls returns the most recent calls of the webhook. The request
+// and response of a call are only available through FetchWebhookCall.
+func (c *Client) FetchWebhookCalls(spaceID string, webhookID string) (calls []*WebhookCall, pagination *Pagination, err error) {
+	if spaceID == "" || webhookID == "" {
+		return nil, nil, fmt.Errorf("FetchWebhookCalls failed. Invalid spaceID or webhookID.")
+	}
+
+	type callsResponse struct {
+		*Pagination
+		Items []*WebhookCall `json:"items"`
+	}
+
+	results := new(callsResponse)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/webhooks/%v/calls", spaceID, webhookID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, results, contentfulError)
+
+	return results.Items, results.Pagination, handleError(err, contentfulError)
+}
+
+// FetchWebhookCall will return a webhook call including the request sent and
+// the response received.
+func (c *Client) FetchWebhookCall(spaceID string, webhookID string, callID string) (call *WebhookCall, err error) {
+	if spaceID == "" || webhookID == "" || callID == "" {
+		return nil, fmt.Errorf("FetchWebhookCall failed. Invalid spaceID, webhookID or callID.")
+	}
+
+	call = new(WebhookCall)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/webhooks/%v/calls/%v", spaceID, webhookID, callID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, call, contentfulError)
+
+	return call, handleError(err, contentfulError)
+}
+
+// FetchWebhookHealth returns how many of the most recent calls of the webhook
+// succeeded.
+func (c *Client) FetchWebhookHealth(spaceID string, webhookID string) (health *WebhookHealth, err error) {
+	if spaceID == "" || webhookID == "" {
+		return nil, fmt.Errorf("FetchWebhookHealth failed. Invalid spaceID or webhookID.")
+	}
+
+	health = new(WebhookHealth)
+	contentfulError := new(Error)
+	path := fmt.Sprintf("spaces/%v/webhooks/%v/health", spaceID, webhookID)
+	req, err := c.sling.New().Get(path).Request()
+
+	if err != nil {
+		return
+	}
+
+	_, err = c.do(req, health, contentfulError)
+
+	return health, handleError(err, contentfulError)
+}
@@ -0,0 +1,115 @@
+package management
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/illyabusigin/contentful/models"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestWebhookValidationFailures(t *testing.T) {
+	var validationTests = []struct {
+		webhook  Webhook
+		expected string
+	}{
+		{Webhook{}, "Empty webhook should return an error"},
+		{Webhook{Name: "test", URL: "example.com", Topics: []string{WebhookTopicAll}}, "Webhook without scheme should return an error"},
+		{Webhook{Name: "test", URL: "https://example.com"}, "Webhook without topics should return an error"},
+		{Webhook{Name: "test", URL: "https://example.com", Topics: []string{WebhookTopicAll}, Headers: []*WebhookHeader{{Value: "x"}}}, "Header without key should return an error"},
+	}
+
+	for _, test := range validationTests {
+		err := test.webhook.Validate()
+		assert.NotNil(t, err, test.expected)
+	}
+}
+
+func TestWebhookSpaceValidationFailures(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	webhook := &Webhook{Name: "test", URL: "https://example.com", Topics: []string{WebhookTopicAll}}
+
+	_, err := client.CreateWebhook("", webhook)
+	assert.NotNil(t, err)
+	assert.Equal(t, "CreateWebhook failed. Space identifier is not valid!", err.Error())
+
+	webhook.ID = "hook123"
+	_, err = client.UpdateWebhook("", webhook)
+	assert.NotNil(t, err)
+	assert.Equal(t, "UpdateWebhook failed. Space identifier is not valid!", err.Error())
+
+	assert.Nil(t, doer.request, "No request should be performed")
+}
+
+func TestCreateWebhookRequest(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	webhook := &Webhook{
+		Name:    "Publish",
+		URL:     "https://example.com/hooks",
+		Topics:  []string{"Entry.publish", "Entry.unpublish"},
+		Headers: []*WebhookHeader{{Key: "X-Token", Value: "abc", Secret: true}},
+		Filters: []WebhookFilter{
+			WebhookFilterEqual("sys.environment.sys.id", "master"),
+			WebhookFilterNot(WebhookFilterIn("sys.contentType.sys.id", "draft", "internal")),
+		},
+		HTTPBasicUsername: "user",
+		HTTPBasicPassword: "pass",
+	}
+
+	_, err := client.CreateWebhook("space123", webhook)
+	req := doer.request
+
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/webhook_definitions", req.URL.String())
+
+	expectedJSON := `{
+		"sys": {},
+		"name": "Publish",
+		"url": "https://example.com/hooks",
+		"topics": ["Entry.publish", "Entry.unpublish"],
+		"headers": [{"key": "X-Token", "value": "abc", "secret": true}],
+		"filters": [
+			{"equals": [{"doc": "sys.environment.sys.id"}, "master"]},
+			{"not": {"in": [{"doc": "sys.contentType.sys.id"}, ["draft", "internal"]]}}
+		],
+		"httpBasicUsername": "user",
+		"httpBasicPassword": "pass"
+	}`
+	requestJSON, _ := ioutil.ReadAll(req.Body)
+	assert.JSONEq(t, expectedJSON, string(requestJSON))
+}
+
+func TestWebhookCallRequests(t *testing.T) {
+	client := NewClient(accessToken, version, nil)
+
+	// Inject request interceptor
+	doer := &interceptor{}
+	doer.err = errIntercept
+	client.sling = client.sling.New().Doer(doer)
+
+	_, _, err := client.FetchWebhookCalls("space123", "hook123")
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/webhooks/hook123/calls", doer.request.URL.String())
+
+	_, err = client.FetchWebhookCall("space123", "hook123", "call123")
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/webhooks/hook123/calls/call123", doer.request.URL.String())
+
+	_, err = client.FetchWebhookHealth("space123", "hook123")
+	assert.Equal(t, errIntercept, err)
+	assert.Equal(t, "https://api.contentful.com/spaces/space123/webhooks/hook123/health", doer.request.URL.String())
+}
@@ -0,0 +1,263 @@
+// Package migration evolves the content model of a space with migrations
+// defined in Go. A migration is a list of steps, such as adding a field or
+// transforming the values of entries:
+//
+//	migrations := []*migration.Migration{
+//		{
+//			ID:          "001-add-author",
+//			Description: "Add an author to posts",
+//			Steps: []migration.Step{
+//				migration.AddField("post", models.Field{ID: "author", Name: "Author", Type: models.ShortText}),
+//				migration.TransformEntries("post", func(entry *models.Entry) (bool, error) {
+//					entry.Fields["author"] = map[string]interface{}{"en-US": "Editorial team"}
+//					return true, nil
+//				}),
+//			},
+//		},
+//	}
+//
+//	plan, err := migration.Run(client, spaceID, migrations, nil)
+//
+// Applied migrations are recorded as entries of a content type in the space,
+// so every migration is only applied once. Migrations run in the order they
+// are passed. A migration that fails is not recorded and is applied again by
+// the next run, the bundled steps skip changes that have already been made.
+package migration
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// DefaultContentTypeID is the content type recording applied migrations
+const DefaultContentTypeID = "migration"
+
+// Migration is a named list of steps
+type Migration struct {
+	// ID identifies the migration, it is used as the ID of the entry
+	// recording the migration. Prefix IDs with a number or date so they sort
+	// in the order they are applied.
+	ID          string
+	Description string
+	Steps       []Step
+}
+
+var idPattern = regexp.MustCompile(`^[a-zA-Z0-9\-_.]{1,64}$`)
+
+// Validate will validate the migration
+func (m *Migration) Validate() error {
+	if !idPattern.MatchString(m.ID) {
+		return fmt.Errorf("Migration validation failed. ID %q must be 1 to 64 letters, digits, dashes, underscores or dots!", m.ID)
+	}
+
+	if len(m.Steps) == 0 {
+		return fmt.Errorf("Migration validation failed. Migration %v has no steps!", m.ID)
+	}
+
+	return nil
+}
+
+// Options configures Run
+type Options struct {
+	// ContentTypeID is the content type recording applied migrations. It is
+	// created by the first run. Defaults to DefaultContentTypeID.
+	ContentTypeID string
+	// DryRun plans the migrations without changing the space
+	DryRun bool
+}
+
+func (o *Options) withDefaults() *Options {
+	options := Options{}
+	if o != nil {
+		options = *o
+	}
+
+	if options.ContentTypeID == "" {
+		options.ContentTypeID = DefaultContentTypeID
+	}
+
+	return &options
+}
+
+// Plan lists the migrations that have already been applied and those that are
+// applied by Run
+type Plan struct {
+	Applied []string
+	Pending []*Migration
+}
+
+// String describes the pending migrations and their steps
+func (p *Plan) String() string {
+	if len(p.Pending) == 0 {
+		return fmt.Sprintf("No pending migrations, %v applied.\n", len(p.Applied))
+	}
+
+	b := &strings.Builder{}
+	for _, m := range p.Pending {
+		fmt.Fprintf(b, "Migration %v", m.ID)
+		if m.Description != "" {
+			fmt.Fprintf(b, ": %v", m.Description)
+		}
+		b.WriteString("\n")
+
+		for _, step := range m.Steps {
+			fmt.Fprintf(b, "  - %v\n", step)
+		}
+	}
+
+	return b.String()
+}
+
+// Run applies the migrations that have not been applied to the space yet and
+// returns the plan it followed. With Options.DryRun the plan is returned
+// without applying it.
+func Run(client *management.Client, spaceID string, migrations []*Migration, options *Options) (*Plan, error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("Migration failed. Space identifier is not valid!")
+	}
+
+	options = options.withDefaults()
+
+	ids := map[string]bool{}
+	for _, m := range migrations {
+		if err := m.Validate(); err != nil {
+			return nil, err
+		}
+
+		if ids[m.ID] {
+			return nil, fmt.Errorf("Migration failed. Migration %v is defined twice!", m.ID)
+		}
+
+		ids[m.ID] = true
+	}
+
+	target := &Target{Client: client, SpaceID: spaceID}
+
+	recorder, err := loadRecorder(target, options.ContentTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Applied: []string{}, Pending: []*Migration{}}
+	for _, m := range migrations {
+		if recorder.applied[m.ID] {
+			plan.Applied = append(plan.Applied, m.ID)
+		} else {
+			plan.Pending = append(plan.Pending, m)
+		}
+	}
+
+	if options.DryRun || len(plan.Pending) == 0 {
+		return plan, nil
+	}
+
+	if err = recorder.ensureContentType(); err != nil {
+		return plan, err
+	}
+
+	for _, m := range plan.Pending {
+		for i, step := range m.Steps {
+			if err = step.Apply(target); err != nil {
+				return plan, fmt.Errorf("Migration %v failed. Step %v (%v): %w", m.ID, i+1, step, err)
+			}
+		}
+
+		if err = recorder.record(m); err != nil {
+			return plan, fmt.Errorf("Migration %v failed. Recording the migration: %w", m.ID, err)
+		}
+	}
+
+	return plan, nil
+}
+
+// recorder reads and writes the entries recording applied migrations
+type recorder struct {
+	target        *Target
+	contentTypeID string
+	contentType   *ContentType
+	applied       map[string]bool
+}
+
+func loadRecorder(target *Target, contentTypeID string) (*recorder, error) {
+	r := &recorder{target: target, contentTypeID: contentTypeID, applied: map[string]bool{}}
+
+	contentType, err := target.Client.FetchContentType(target.SpaceID, contentTypeID)
+	if isNotFound(err) {
+		return r, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Migration failed. Fetching applied migrations: %w", err)
+	}
+
+	r.contentType = target.withSpace(contentType)
+
+	it := target.Client.IterateEntries(target.SpaceID, NewQuery().ContentType(contentTypeID))
+	for it.Next() {
+		r.applied[it.Entry().ID] = true
+	}
+
+	if err = it.Err(); err != nil {
+		return nil, fmt.Errorf("Migration failed. Fetching applied migrations: %w", err)
+	}
+
+	return r, nil
+}
+
+func (r *recorder) ensureContentType() error {
+	if r.contentType != nil {
+		return nil
+	}
+
+	err := CreateContentType(&ContentType{
+		System:       System{ID: r.contentTypeID},
+		Name:         "Migration",
+		Description:  "Content model migrations applied to the space",
+		DisplayField: "id",
+		Fields: []Field{
+			{ID: "id", Name: "ID", Type: ShortText, Required: true},
+			{ID: "description", Name: "Description", Type: LongText},
+			{ID: "appliedAt", Name: "Applied at", Type: Date},
+		},
+	}).Apply(r.target)
+	if err != nil {
+		return fmt.Errorf("Migration failed. Creating the %v content type: %w", r.contentTypeID, err)
+	}
+
+	contentType, err := r.target.Client.FetchContentType(r.target.SpaceID, r.contentTypeID)
+	if err != nil {
+		return err
+	}
+
+	r.contentType = r.target.withSpace(contentType)
+
+	return nil
+}
+
+func (r *recorder) record(m *Migration) error {
+	locale, err := r.target.DefaultLocale()
+	if err != nil {
+		return err
+	}
+
+	fields := EntryFields{
+		"id":        map[string]interface{}{locale: m.ID},
+		"appliedAt": map[string]interface{}{locale: time.Now().UTC().Format(time.RFC3339)},
+	}
+
+	if m.Description != "" {
+		fields["description"] = map[string]interface{}{locale: m.Description}
+	}
+
+	_, err = r.target.Client.CreateEntry(&NewEntry{ID: m.ID, Fields: fields}, r.contentType)
+	if err != nil {
+		return err
+	}
+
+	r.applied[m.ID] = true
+
+	return nil
+}
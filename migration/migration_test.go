@@ -0,0 +1,311 @@
+package migration
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+
+	"github.com/illyabusigin/contentful/contentfultest"
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+func value(entry *Entry, field string) interface{} {
+	values, _ := entry.Fields[field].(map[string]interface{})
+	return values["en-US"]
+}
+
+// createPosts creates a post content type with a published and a draft post
+func createPosts(t *testing.T, client *management.Client) {
+	err := CreateContentType(&ContentType{
+		System:       System{ID: "post"},
+		Name:         "Post",
+		DisplayField: "title",
+		Fields: []Field{
+			{ID: "title", Name: "Title", Type: ShortText, Required: true},
+			{ID: "body", Name: "Body", Type: LongText},
+			{ID: "byline", Name: "Byline", Type: ShortText},
+		},
+	}).Apply(&Target{Client: client, SpaceID: "space"})
+	assert.Nil(t, err)
+
+	contentType, err := client.FetchContentType("space", "post")
+	assert.Nil(t, err)
+
+	for _, id := range []string{"published", "draft"} {
+		entry, err := client.CreateEntry(&NewEntry{ID: id, Fields: EntryFields{
+			"title":  map[string]interface{}{"en-US": id},
+			"body":   map[string]interface{}{"en-US": id + " body"},
+			"byline": map[string]interface{}{"en-US": "Jane " + id},
+		}}, contentType)
+		assert.Nil(t, err)
+
+		if id == "published" {
+			_, err = client.PublishEntry(entry)
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func migrations() []*Migration {
+	maxTitle := float64(100)
+
+	return []*Migration{
+		{
+			ID:          "001-authors",
+			Description: "Move bylines to author entries",
+			Steps: []Step{
+				CreateContentType(&ContentType{
+					System:       System{ID: "author"},
+					Name:         "Author",
+					DisplayField: "name",
+					Fields:       []Field{{ID: "name", Name: "Name", Type: ShortText}},
+				}),
+				AddField("post", Field{ID: "author", Name: "Author", Type: LinkType, LinkType: "Entry"}),
+				DeriveEntries("post", "author", "author", func(entry *Entry) (*NewEntry, error) {
+					return &NewEntry{ID: "author-" + entry.ID, Fields: EntryFields{"name": entry.Fields["byline"]}}, nil
+				}),
+				DeleteField("post", "byline"),
+			},
+		},
+		{
+			ID: "002-content",
+			Steps: []Step{
+				RenameField("post", "body", "content"),
+				ChangeValidations("post", "title", FieldValidation{Size: &SizeFieldValidation{Max: &maxTitle}}),
+			},
+		},
+		{
+			ID: "003-titles",
+			Steps: []Step{
+				TransformEntries("post", func(entry *Entry) (bool, error) {
+					entry.Fields["title"] = map[string]interface{}{"en-US": strings.ToUpper(value(entry, "title").(string))}
+					return true, nil
+				}),
+			},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+	server.CreateSpace("space", "Space")
+
+	writes := &contentfultest.Writes{}
+	client := server.ManagementClient(management.WithMiddleware(writes.Middleware))
+	createPosts(t, client)
+
+	plan, err := Run(client, "space", migrations(), nil)
+	assert.Nil(t, err)
+	assert.Empty(t, plan.Applied)
+	assert.Len(t, plan.Pending, 3)
+
+	contentType, err := client.FetchContentType("space", "post")
+	assert.Nil(t, err)
+	assert.True(t, contentType.IsPublished())
+	assert.False(t, contentType.IsChanged())
+
+	ids := []string{}
+	for _, field := range contentType.Fields {
+		ids = append(ids, field.ID)
+	}
+	assert.Equal(t, []string{"title", "author", "content"}, ids)
+	assert.Equal(t, float64(100), *contentType.Fields[0].Validations[0].Size.Max)
+
+	published, err := client.FetchEntry("space", "published")
+	assert.Nil(t, err)
+	assert.True(t, published.IsPublished())
+	assert.False(t, published.IsChanged())
+	assert.Equal(t, "PUBLISHED", value(published, "title"))
+	assert.Equal(t, "published body", value(published, "content"))
+	assert.Equal(t, "author-published", value(published, "author").(map[string]interface{})["sys"].(map[string]interface{})["id"])
+
+	author, err := client.FetchEntry("space", "author-published")
+	assert.Nil(t, err)
+	assert.True(t, author.IsPublished())
+	assert.Equal(t, "Jane published", value(author, "name"))
+
+	draft, err := client.FetchEntry("space", "draft")
+	assert.Nil(t, err)
+	assert.False(t, draft.IsPublished())
+	assert.Equal(t, "DRAFT", value(draft, "title"))
+
+	author, err = client.FetchEntry("space", "author-draft")
+	assert.Nil(t, err)
+	assert.False(t, author.IsPublished())
+
+	applied, err := client.FetchEntry("space", "002-content")
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultContentTypeID, applied.ContentType.ID)
+
+	// Running again changes nothing
+	writes.Reset()
+	plan, err = Run(client, "space", migrations(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"001-authors", "002-content", "003-titles"}, plan.Applied)
+	assert.Empty(t, plan.Pending)
+	assert.Empty(t, writes.All())
+}
+
+func TestRunFailure(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+	server.CreateSpace("space", "Space")
+
+	client := server.ManagementClient()
+	createPosts(t, client)
+
+	failing := migrations()
+	failing[1].Steps = append(failing[1].Steps, Func("fail", func(target *Target) error {
+		return errors.New("boom")
+	}))
+
+	_, err := Run(client, "space", failing, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Migration 002-content failed. Step 3 (fail): boom")
+
+	// The failed migration is applied again, its completed steps are skipped
+	plan, err := Run(client, "space", migrations(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"001-authors"}, plan.Applied)
+	assert.Len(t, plan.Pending, 2)
+
+	published, err := client.FetchEntry("space", "published")
+	assert.Nil(t, err)
+	assert.Equal(t, "published body", value(published, "content"))
+}
+
+func TestDryRun(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+	server.CreateSpace("space", "Space")
+
+	writes := &contentfultest.Writes{}
+	client := server.ManagementClient(management.WithMiddleware(writes.Middleware))
+
+	plan, err := Run(client, "space", migrations()[1:], &Options{DryRun: true})
+	assert.Nil(t, err)
+	assert.Empty(t, writes.All())
+	assert.Equal(t, `Migration 002-content
+  - rename field body of post to content
+  - change validations of field title of post
+Migration 003-titles
+  - transform entries of post
+`, plan.String())
+
+	_, err = client.FetchContentType("space", DefaultContentTypeID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestDeleteField(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+	server.CreateSpace("space", "Space")
+
+	writes := &contentfultest.Writes{}
+	client := server.ManagementClient(management.WithMiddleware(writes.Middleware))
+	createPosts(t, client)
+
+	// Record the fields sent by content type updates
+	updates := [][]Field{}
+	record := func() {
+		for _, write := range writes.All() {
+			if write.String() == "PUT content_types/post" {
+				contentType := &ContentType{}
+				assert.Nil(t, json.Unmarshal(write.Body, contentType))
+				updates = append(updates, contentType.Fields)
+			}
+		}
+
+		writes.Reset()
+	}
+
+	target := &Target{Client: client, SpaceID: "space"}
+	writes.Reset()
+
+	assert.Nil(t, DeleteField("post", "byline").Apply(target))
+	record()
+	assert.Len(t, updates, 2)
+	assert.Len(t, updates[0], 3)
+	assert.True(t, updates[0][2].Omitted)
+	assert.Len(t, updates[1], 2)
+
+	// Deleting a missing field does nothing
+	assert.Nil(t, DeleteField("post", "byline").Apply(target))
+	record()
+	assert.Len(t, updates, 2)
+}
+
+func TestRenameFieldArchived(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+	server.CreateSpace("space", "Space")
+
+	client := server.ManagementClient()
+	createPosts(t, client)
+
+	draft, err := client.FetchEntry("space", "draft")
+	assert.Nil(t, err)
+	_, err = client.ArchiveEntry(draft)
+	assert.Nil(t, err)
+
+	target := &Target{Client: client, SpaceID: "space"}
+	assert.Nil(t, RenameField("post", "body", "content").Apply(target))
+
+	// Archived entries keep their values and stay archived
+	archived, err := client.FetchEntry("space", "draft")
+	assert.Nil(t, err)
+	assert.True(t, archived.IsArchived())
+	assert.Equal(t, "draft body", value(archived, "content"))
+
+	published, err := client.FetchEntry("space", "published")
+	assert.Nil(t, err)
+	assert.True(t, published.IsPublished())
+	assert.Equal(t, "published body", value(published, "content"))
+}
+
+func TestRenameFieldChanged(t *testing.T) {
+	server := contentfultest.NewServer()
+	defer server.Close()
+	server.CreateSpace("space", "Space")
+
+	client := server.ManagementClient()
+	createPosts(t, client)
+
+	// The published entry has pending changes
+	entry, err := client.FetchEntry("space", "published")
+	assert.Nil(t, err)
+	entry.Fields["body"] = map[string]interface{}{"en-US": "changed body"}
+	entry, err = client.UpdateEntry(entry)
+	assert.Nil(t, err)
+	assert.True(t, entry.IsChanged())
+
+	target := &Target{Client: client, SpaceID: "space"}
+	assert.Nil(t, RenameField("post", "body", "content").Apply(target))
+
+	// The value is copied to the draft, which isn't published
+	changed, err := client.FetchEntry("space", "published")
+	assert.Nil(t, err)
+	assert.Equal(t, "changed body", value(changed, "content"))
+	assert.True(t, changed.IsChanged())
+	assert.Equal(t, entry.PublishedVersion, changed.PublishedVersion)
+}
+
+func TestValidate(t *testing.T) {
+	step := Func("nothing", func(target *Target) error { return nil })
+
+	assert.Nil(t, (&Migration{ID: "2017-01-01.add_authors", Steps: []Step{step}}).Validate())
+	assert.NotNil(t, (&Migration{ID: "", Steps: []Step{step}}).Validate())
+	assert.NotNil(t, (&Migration{ID: "add authors", Steps: []Step{step}}).Validate())
+	assert.NotNil(t, (&Migration{ID: "empty"}).Validate())
+
+	client := management.New("token")
+	duplicate := []*Migration{{ID: "one", Steps: []Step{step}}, {ID: "one", Steps: []Step{step}}}
+	_, err := Run(client, "space", duplicate, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "defined twice")
+}
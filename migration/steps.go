@@ -0,0 +1,445 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// Target is the space migrations are applied to
+type Target struct {
+	Client  *management.Client
+	SpaceID string
+
+	defaultLocale string
+}
+
+// DefaultLocale returns the code of the default locale of the space
+func (t *Target) DefaultLocale() (string, error) {
+	if t.defaultLocale != "" {
+		return t.defaultLocale, nil
+	}
+
+	it := t.Client.IterateLocales(t.SpaceID)
+	for it.Next() {
+		if it.Locale().Default {
+			t.defaultLocale = it.Locale().Code
+			return t.defaultLocale, nil
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("Space %v has no default locale!", t.SpaceID)
+}
+
+// UpdateContentType fetches the content type and passes it to edit. If edit
+// reports a change the content type is updated and activated.
+func (t *Target) UpdateContentType(contentTypeID string, edit func(contentType *ContentType) (bool, error)) error {
+	contentType, err := t.Client.FetchContentType(t.SpaceID, contentTypeID)
+	if err != nil {
+		return err
+	}
+
+	changed, err := edit(t.withSpace(contentType))
+	if err != nil || !changed {
+		return err
+	}
+
+	updated, err := t.Client.UpdateContentType(contentType)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.Client.ActivateContentType(t.withSpace(updated))
+
+	return err
+}
+
+// eachEntry calls fn for every entry of the content type, archived entries are
+// only included if archived is set. Values of deleted fields are removed from
+// the entries passed to fn.
+func (t *Target) eachEntry(contentTypeID string, archived bool, fn func(entry *Entry) error) error {
+	contentType, err := t.Client.FetchContentType(t.SpaceID, contentTypeID)
+	if err != nil {
+		return err
+	}
+
+	// Updating entries doesn't change their creation date, so pages don't
+	// shift while entries are updated
+	query := NewQuery().ContentType(contentTypeID).Order("sys.createdAt", "sys.id")
+
+	it := t.Client.IterateEntries(t.SpaceID, query)
+	for it.Next() {
+		entry := it.Entry()
+		if entry.IsArchived() && !archived {
+			continue
+		}
+
+		for id := range entry.Fields {
+			if findField(contentType, id) == nil {
+				delete(entry.Fields, id)
+			}
+		}
+
+		if entry.Space == nil {
+			entry.Space = t.spaceLink()
+		}
+
+		if err := fn(entry); err != nil {
+			return fmt.Errorf("entry %v: %w", entry.ID, err)
+		}
+	}
+
+	return it.Err()
+}
+
+// publishUpdated updates the entry and publishes it again if it was published
+// and had no pending changes
+func (t *Target) publishUpdated(entry *Entry) error {
+	published := entry.IsPublished() && !entry.IsChanged()
+
+	updated, err := t.Client.UpdateEntry(entry)
+	if err != nil || !published {
+		return err
+	}
+
+	_, err = t.Client.PublishEntry(updated)
+
+	return err
+}
+
+// updateArchived unarchives the entry to update it and archives it again
+func (t *Target) updateArchived(entry *Entry) error {
+	unarchived, err := t.Client.UnarchiveEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	unarchived.Fields = entry.Fields
+	if unarchived.Space == nil {
+		unarchived.Space = t.spaceLink()
+	}
+
+	updated, err := t.Client.UpdateEntry(unarchived)
+	if err != nil {
+		return err
+	}
+
+	if updated.Space == nil {
+		updated.Space = t.spaceLink()
+	}
+
+	_, err = t.Client.ArchiveEntry(updated)
+
+	return err
+}
+
+func (t *Target) spaceLink() *Link {
+	return &Link{LinkData: &LinkData{Type: LinkType, LinkType: "Space", ID: t.SpaceID}}
+}
+
+func (t *Target) withSpace(contentType *ContentType) *ContentType {
+	if contentType.Space == nil {
+		contentType.Space = t.spaceLink()
+	}
+
+	return contentType
+}
+
+// Step is a single change of a migration
+type Step interface {
+	// String describes the change for plans
+	String() string
+	// Apply makes the change
+	Apply(target *Target) error
+}
+
+// Func returns a step that calls apply
+func Func(description string, apply func(target *Target) error) Step {
+	return &step{description: description, apply: apply}
+}
+
+type step struct {
+	description string
+	apply       func(target *Target) error
+}
+
+func (s *step) String() string {
+	return s.description
+}
+
+func (s *step) Apply(target *Target) error {
+	return s.apply(target)
+}
+
+// CreateContentType creates and activates the content type. Nothing is done if
+// a content type with the same ID exists.
+func CreateContentType(contentType *ContentType) Step {
+	return Func(fmt.Sprintf("create content type %v", contentType.ID), func(target *Target) error {
+		_, err := target.Client.FetchContentType(target.SpaceID, contentType.ID)
+		if err == nil || !isNotFound(err) {
+			return err
+		}
+
+		created := *contentType
+		created.System = System{ID: contentType.ID, Space: target.spaceLink()}
+
+		activated, err := target.Client.CreateContentType(&created)
+		if err != nil {
+			return err
+		}
+
+		_, err = target.Client.ActivateContentType(target.withSpace(activated))
+
+		return err
+	})
+}
+
+// AddField adds the field to the content type. Nothing is done if the content
+// type has a field with the same ID.
+func AddField(contentTypeID string, field Field) Step {
+	return Func(fmt.Sprintf("add field %v to %v", field.ID, contentTypeID), func(target *Target) error {
+		return target.UpdateContentType(contentTypeID, func(contentType *ContentType) (bool, error) {
+			if findField(contentType, field.ID) != nil {
+				return false, nil
+			}
+
+			contentType.Fields = append(contentType.Fields, field)
+
+			return true, nil
+		})
+	})
+}
+
+// EditField changes a field of the content type, for example its name or
+// whether it is required
+func EditField(contentTypeID string, fieldID string, edit func(field *Field)) Step {
+	return Func(fmt.Sprintf("edit field %v of %v", fieldID, contentTypeID), func(target *Target) error {
+		return target.UpdateContentType(contentTypeID, func(contentType *ContentType) (bool, error) {
+			field := findField(contentType, fieldID)
+			if field == nil {
+				return false, fmt.Errorf("field %v does not exist", fieldID)
+			}
+
+			edit(field)
+
+			return true, nil
+		})
+	})
+}
+
+// ChangeValidations replaces the validations of a field
+func ChangeValidations(contentTypeID string, fieldID string, validations ...FieldValidation) Step {
+	edit := EditField(contentTypeID, fieldID, func(field *Field) {
+		field.Validations = validations
+	})
+
+	return Func(fmt.Sprintf("change validations of field %v of %v", fieldID, contentTypeID), edit.Apply)
+}
+
+// OmitField omits the field from the delivery APIs. The values of the field
+// are kept and it can be shown again with EditField.
+func OmitField(contentTypeID string, fieldID string) Step {
+	return Func(fmt.Sprintf("omit field %v of %v", fieldID, contentTypeID), func(target *Target) error {
+		return target.UpdateContentType(contentTypeID, func(contentType *ContentType) (bool, error) {
+			field := findField(contentType, fieldID)
+			if field == nil || field.Omitted {
+				return false, nil
+			}
+
+			field.Omitted = true
+
+			return true, nil
+		})
+	})
+}
+
+// DeleteField deletes the field and its values. Contentful only deletes
+// fields that have been omitted first, so the field is omitted and the content
+// type activated before the field is removed.
+func DeleteField(contentTypeID string, fieldID string) Step {
+	return Func(fmt.Sprintf("delete field %v of %v", fieldID, contentTypeID), func(target *Target) error {
+		if err := OmitField(contentTypeID, fieldID).Apply(target); err != nil {
+			return err
+		}
+
+		return target.UpdateContentType(contentTypeID, func(contentType *ContentType) (bool, error) {
+			for i, field := range contentType.Fields {
+				if field.ID != fieldID {
+					continue
+				}
+
+				contentType.Fields = append(contentType.Fields[:i:i], contentType.Fields[i+1:]...)
+				if contentType.DisplayField == fieldID {
+					contentType.DisplayField = ""
+				}
+
+				return true, nil
+			}
+
+			return false, nil
+		})
+	})
+}
+
+// RenameField changes the ID of a field. A field with the new ID is added, the
+// values of every entry are copied to it and the old field is deleted.
+// Archived entries are unarchived to copy their values and archived again.
+//
+// Published entries with pending changes are not published again, that would
+// publish their pending changes too. Their values are only copied to the
+// draft, so the renamed field is empty in the published version until the
+// entry is published again.
+func RenameField(contentTypeID string, fieldID string, newID string) Step {
+	return Func(fmt.Sprintf("rename field %v of %v to %v", fieldID, contentTypeID, newID), func(target *Target) error {
+		contentType, err := target.Client.FetchContentType(target.SpaceID, contentTypeID)
+		if err != nil {
+			return err
+		}
+
+		field := findField(contentType, fieldID)
+		if field == nil {
+			if findField(contentType, newID) != nil {
+				return nil
+			}
+
+			return fmt.Errorf("field %v does not exist", fieldID)
+		}
+
+		renamed := *field
+		renamed.ID = newID
+
+		steps := []Step{
+			AddField(contentTypeID, renamed),
+			Func("", func(target *Target) error {
+				return target.eachEntry(contentTypeID, true, func(entry *Entry) error {
+					value, ok := entry.Fields[fieldID]
+					if !ok || entry.Fields[newID] != nil {
+						return nil
+					}
+
+					entry.Fields[newID] = value
+					if entry.IsArchived() {
+						return target.updateArchived(entry)
+					}
+
+					return target.publishUpdated(entry)
+				})
+			}),
+		}
+
+		if contentType.DisplayField == fieldID {
+			steps = append(steps, Func("", func(target *Target) error {
+				return target.UpdateContentType(contentTypeID, func(contentType *ContentType) (bool, error) {
+					contentType.DisplayField = newID
+					return true, nil
+				})
+			}))
+		}
+
+		steps = append(steps, DeleteField(contentTypeID, fieldID))
+
+		for _, step := range steps {
+			if err = step.Apply(target); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// TransformEntries passes every entry of the content type to transform, which
+// changes the fields of the entry and reports whether it did. Changed entries
+// are updated and published again if they were published. Archived entries are
+// left untouched.
+func TransformEntries(contentTypeID string, transform func(entry *Entry) (bool, error)) Step {
+	return Func(fmt.Sprintf("transform entries of %v", contentTypeID), func(target *Target) error {
+		return target.eachEntry(contentTypeID, false, func(entry *Entry) error {
+			changed, err := transform(entry)
+			if err != nil || !changed {
+				return err
+			}
+
+			return target.publishUpdated(entry)
+		})
+	})
+}
+
+// DeriveEntries creates an entry of the content type to for every entry of the
+// content type from. derive returns the new entry, or nil to skip the entry.
+// Set NewEntry.ID so derived entries aren't created twice if the migration is
+// applied again.
+//
+// If linkField is set, it is set to a link to the derived entry in the default
+// locale, and entries whose linkField is already set are skipped. Derived
+// entries are published if the entry they were derived from is published.
+func DeriveEntries(from string, to string, linkField string, derive func(entry *Entry) (*NewEntry, error)) Step {
+	description := fmt.Sprintf("derive %v entries from %v entries", to, from)
+	if linkField != "" {
+		description += fmt.Sprintf(" linked through %v", linkField)
+	}
+
+	return Func(description, func(target *Target) error {
+		contentType, err := target.Client.FetchContentType(target.SpaceID, to)
+		if err != nil {
+			return err
+		}
+
+		locale, err := target.DefaultLocale()
+		if err != nil {
+			return err
+		}
+
+		return target.eachEntry(from, false, func(entry *Entry) error {
+			if linkField != "" && entry.Fields[linkField] != nil {
+				return nil
+			}
+
+			newEntry, err := derive(entry)
+			if err != nil || newEntry == nil {
+				return err
+			}
+
+			derived, err := target.Client.CreateEntry(newEntry, target.withSpace(contentType))
+			switch {
+			case errors.Is(err, ErrVersionMismatch) && newEntry.ID != "":
+				// Derived by a previous, failed attempt
+				derived = &Entry{System: System{ID: newEntry.ID}}
+			case err != nil:
+				return err
+			case entry.IsPublished() && !entry.IsChanged():
+				if _, err = target.Client.PublishEntry(derived); err != nil {
+					return err
+				}
+			}
+
+			if linkField == "" {
+				return nil
+			}
+
+			entry.Fields[linkField] = map[string]interface{}{locale: derived.Link()}
+
+			return target.publishUpdated(entry)
+		})
+	})
+}
+
+func findField(contentType *ContentType, fieldID string) *Field {
+	for i := range contentType.Fields {
+		if contentType.Fields[i].ID == fieldID {
+			return &contentType.Fields[i]
+		}
+	}
+
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
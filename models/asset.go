@@ -18,23 +18,25 @@ type Asset struct {
 
 // AssetFields contains all asset information.
 type AssetFields struct {
-	Title map[string]string    `json:"title,omitempty"`
-	File  map[string]AssetData `json:"file,omitempty"`
+	Title       map[string]string    `json:"title,omitempty"`
+	Description map[string]string    `json:"description,omitempty"`
+	File        map[string]AssetData `json:"file,omitempty"`
 }
 
-// AssetData contains all asset information
+// AssetData contains all asset information. URL is only set once the file has
+// been processed, until then Upload or UploadFrom reference the source file.
 type AssetData struct {
-	MIMEType string       `json:"contentType"`
-	Name     string       `json:"fileName"`
-	URL      string       `json:"url,omitempty"`
-	Upload   string       `json:"upload,omitempty"`
-	Detail   *AssetDetail `json:"details,omitempty"`
+	MIMEType   string       `json:"contentType"`
+	Name       string       `json:"fileName"`
+	URL        string       `json:"url,omitempty"`
+	Upload     string       `json:"upload,omitempty"`
+	UploadFrom *Link        `json:"uploadFrom,omitempty"`
+	Detail     *AssetDetail `json:"details,omitempty"`
 }
 
 // Validate will validate the Asset to ensure all necessary fields are present.
 func (a *Asset) Validate() error {
 	if a.System.ID == "" {
-		fmt.Println("system ID is empty!")
 		return fmt.Errorf("Asset validation failed. System.ID cannot be empty!")
 	}
 
@@ -69,20 +71,26 @@ type AssetDetail struct {
 // File represents all asset data prior to upload
 type File struct {
 	SpaceID string `json:"-"`
+	// ID is the identifier of the created asset. It is generated by Contentful
+	// if empty.
+	ID string `json:"-"`
 
 	Fields FileFields `json:"fields"`
 }
 
 type FileFields struct {
-	Title map[string]string   `json:"title"`
-	File  map[string]FileData `json:"file"`
+	Title       map[string]string   `json:"title"`
+	Description map[string]string   `json:"description,omitempty"`
+	File        map[string]FileData `json:"file"`
 }
 
-// FileData contains all file information
+// FileData contains all file information. The file is either fetched from URL
+// or taken from a previous upload referenced by UploadFrom.
 type FileData struct {
-	MIMEType string `json:"contentType"`
-	Name     string `json:"fileName,omitempty"`
-	URL      string `json:"upload,omitempty"`
+	MIMEType   string `json:"contentType"`
+	Name       string `json:"fileName,omitempty"`
+	URL        string `json:"upload,omitempty"`
+	UploadFrom *Link  `json:"uploadFrom,omitempty"`
 }
 
 func (f *File) Validate() error {
@@ -103,8 +111,10 @@ func (f *File) Validate() error {
 			return fmt.Errorf("Filed validation failed. FileData.Name cannot be empty. FileData: %v", data)
 		} else if data.MIMEType == "" {
 			return fmt.Errorf("Filed validation failed. FileData.MIMEType cannot be empty. FileData: %v", data)
-		} else if data.URL == "" {
-			return fmt.Errorf("Filed validation failed. FileData.URL cannot be empty. FileData: %v", data)
+		} else if data.URL == "" && data.UploadFrom == nil {
+			return fmt.Errorf("Filed validation failed. FileData.URL or FileData.UploadFrom must be set. FileData: %v", data)
+		} else if data.URL != "" && data.UploadFrom != nil {
+			return fmt.Errorf("Filed validation failed. FileData.URL and FileData.UploadFrom cannot both be set. FileData: %v", data)
 		}
 	}
 
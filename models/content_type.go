@@ -65,17 +65,17 @@ type RegExFieldValidation struct {
 }
 
 // RangeFieldValidation takes optional min and max parameters and validates the range
-// of a value.
+// of a value. Nil bounds are not checked.
 type RangeFieldValidation struct {
-	Min int `json:"min,omitempty"`
-	Max int `json:"max,omitempty"`
+	Min *int `json:"min,omitempty"`
+	Max *int `json:"max,omitempty"`
 }
 
 // SizeFieldValidation permits validation with size. You can specify
-// either minimum size, maximum size, or both.
+// either minimum size, maximum size, or both. Nil bounds are not checked.
 type SizeFieldValidation struct {
-	Min float64 `json:"min,omitempty"`
-	Max float64 `json:"max,omitempty"`
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
 }
 
 // AssetImageValidation permits asset validation around size
@@ -0,0 +1,431 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// GeoPoint is the value of a Location field
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// dateLayouts are the formats Date fields can be stored in
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	geoPointType = reflect.TypeOf(GeoPoint{})
+	systemType   = reflect.TypeOf(System{})
+)
+
+// defaultMaxDepth limits how deep linked entries are decoded
+const defaultMaxDepth = 10
+
+// A Decoder decodes the fields of entries into structs. Struct fields are
+// mapped to entry fields with the contentful tag:
+//
+//	type Post struct {
+//		ID        string    `contentful:"sys.id"`
+//		Title     string    `contentful:"title"`
+//		Published time.Time `contentful:"publishDate"`
+//		Location  GeoPoint  `contentful:"location"`
+//		Author    *Author   `contentful:"author"`
+//		Tags      []string  `contentful:"tags"`
+//		ImageIDs  []string  `contentful:"images"`
+//	}
+//
+// Values are taken from Locale and fall back to DefaultLocale. Date fields are
+// decoded into time.Time, Location fields into GeoPoint and Object fields into
+// structs using their json tags. Links are decoded into the ID of the linked
+// resource for string fields, into *Entry or *Asset, or into nested structs.
+// Nested structs require the link to be resolved, see
+// QueryEntriesResult.ResolveLinks, or the linked entry to be part of Includes.
+// Assets decoded into structs provide the title and file fields, the latter
+// decodes into AssetData.
+//
+// The sys.id, sys.version, sys.contentType, sys.createdAt, sys.updatedAt and
+// sys.publishedAt tags map the system properties of the entry.
+type Decoder struct {
+	Locale        string
+	DefaultLocale string
+
+	// Includes are used to look up links that have not been resolved
+	Includes *Includes
+	// MaxDepth limits how deep linked entries are decoded, links below are
+	// left empty. Defaults to 10.
+	MaxDepth int
+}
+
+// NewDecoder creates a decoder for the locale that falls back to the default
+// locale.
+func NewDecoder(locale string, defaultLocale string) *Decoder {
+	return &Decoder{
+		Locale:        locale,
+		DefaultLocale: defaultLocale,
+		MaxDepth:      defaultMaxDepth,
+	}
+}
+
+// DecodeEntry decodes the fields of the entry in the given locale into v, which
+// must be a pointer to a struct.
+func DecodeEntry(entry *Entry, v interface{}, locale string, defaultLocale string) error {
+	return NewDecoder(locale, defaultLocale).Decode(entry, v)
+}
+
+// Decode decodes the fields of the entry into v, which must be a pointer to a
+// struct.
+func (d *Decoder) Decode(entry *Entry, v interface{}) error {
+	if entry == nil {
+		return fmt.Errorf("Decode failed. Entry cannot be nil!")
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Decode failed. Target must be a non-nil pointer to a struct, got %T", v)
+	}
+
+	return d.decodeEntry(entry, rv.Elem(), 0)
+}
+
+func (d *Decoder) decodeEntry(entry *Entry, rv reflect.Value, depth int) error {
+	for _, f := range structFields(rv.Type()) {
+		field := rv.Field(f.index)
+
+		if strings.HasPrefix(f.name, "sys.") {
+			if err := d.decodeSys(&entry.System, f.name, field); err != nil {
+				return fmt.Errorf("Decode failed. Entry %v: %v", entry.ID, err)
+			}
+			continue
+		}
+
+		value, ok := d.localized(entry.Fields[f.name])
+		if !ok {
+			continue
+		}
+
+		if err := d.decodeValue(value, field, depth); err != nil {
+			return fmt.Errorf("Decode failed. Entry %v, field %v: %v", entry.ID, f.name, err)
+		}
+	}
+
+	return nil
+}
+
+// localized returns the value of the field in the decoder's locale or the
+// default locale
+func (d *Decoder) localized(field interface{}) (interface{}, bool) {
+	locales, ok := field.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	if value, ok := locales[d.Locale]; ok {
+		return value, true
+	}
+
+	if d.DefaultLocale == "" {
+		return nil, false
+	}
+
+	value, ok := locales[d.DefaultLocale]
+	return value, ok
+}
+
+func (d *Decoder) decodeSys(sys *System, name string, field reflect.Value) error {
+	var value interface{}
+
+	switch name {
+	case "sys.id":
+		value = sys.ID
+	case "sys.version":
+		value = sys.Version
+	case "sys.contentType":
+		if sys.ContentType != nil && sys.ContentType.LinkData != nil {
+			value = sys.ContentType.ID
+		}
+	case "sys.createdAt":
+		value = sys.CreatedAt
+	case "sys.updatedAt":
+		value = sys.UpdatedAt
+	case "sys.publishedAt":
+		value = sys.PublishedAt
+	default:
+		return fmt.Errorf("unknown system property %v", name)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+
+		if field.Kind() != reflect.Ptr {
+			rv = rv.Elem()
+		}
+	}
+
+	if !rv.IsValid() {
+		return nil
+	}
+
+	if !rv.Type().AssignableTo(field.Type()) {
+		return fmt.Errorf("cannot decode %v into %v", name, field.Type())
+	}
+
+	field.Set(rv)
+	return nil
+}
+
+func (d *Decoder) decodeValue(value interface{}, field reflect.Value, depth int) error {
+	if value == nil {
+		return nil
+	}
+
+	// Values such as *Entry, *Asset or AssetData that can be used as is
+	if rv := reflect.ValueOf(value); rv.Type().AssignableTo(field.Type()) {
+		field.Set(rv)
+		return nil
+	}
+
+	if field.Kind() == reflect.Ptr {
+		target := reflect.New(field.Type().Elem())
+		if err := d.decodeValue(value, target.Elem(), depth); err != nil {
+			return err
+		}
+
+		field.Set(target)
+		return nil
+	}
+
+	switch field.Type() {
+	case timeType:
+		s, ok := value.(string)
+		if !ok {
+			return mismatch(value, field)
+		}
+
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				field.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+
+		return fmt.Errorf("cannot parse date %q", s)
+	case geoPointType:
+		return d.decodeJSON(value, field)
+	}
+
+	switch v := value.(type) {
+	case *Entry:
+		return d.decodeLinked(v, field, depth)
+	case *Asset:
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(v.ID)
+			return nil
+		case reflect.Struct:
+			return d.decodeEntry(assetEntry(v), field, depth+1)
+		}
+
+		return mismatch(value, field)
+	case map[string]interface{}:
+		link, ok := linkData(v)
+		if !ok {
+			return d.decodeJSON(value, field)
+		}
+
+		if field.Kind() == reflect.String {
+			field.SetString(link.ID)
+			return nil
+		}
+
+		if linked := d.include(link); linked != nil {
+			return d.decodeValue(linked, field, depth)
+		}
+
+		if field.Kind() == reflect.Struct {
+			// Only the identifier of unresolved links is known
+			sys := System{ID: link.ID, Type: link.LinkType}
+			if embedded := field.FieldByName("System"); embedded.IsValid() && embedded.Type() == systemType {
+				embedded.Set(reflect.ValueOf(sys))
+				return nil
+			}
+
+			return d.decodeEntry(&Entry{System: sys}, field, depth)
+		}
+
+		return mismatch(value, field)
+	case []interface{}:
+		if field.Kind() != reflect.Slice {
+			return mismatch(value, field)
+		}
+
+		slice := reflect.MakeSlice(field.Type(), len(v), len(v))
+		for i, item := range v {
+			if err := d.decodeValue(item, slice.Index(i), depth); err != nil {
+				return err
+			}
+		}
+
+		field.Set(slice)
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Type().ConvertibleTo(field.Type()) && rv.Kind() != reflect.String && field.Kind() != reflect.String {
+		field.Set(rv.Convert(field.Type()))
+		return nil
+	}
+
+	if rv.Kind() == reflect.String && field.Kind() == reflect.String {
+		field.SetString(rv.String())
+		return nil
+	}
+
+	if rv.Kind() == reflect.Struct && field.Kind() == reflect.Struct {
+		return d.decodeJSON(value, field)
+	}
+
+	return mismatch(value, field)
+}
+
+// assetEntry represents the asset as an entry with title and file fields, so
+// it can be decoded like linked entries
+func assetEntry(asset *Asset) *Entry {
+	title := map[string]interface{}{}
+	for locale, value := range asset.Fields.Title {
+		title[locale] = value
+	}
+
+	file := map[string]interface{}{}
+	for locale, value := range asset.Fields.File {
+		file[locale] = value
+	}
+
+	return &Entry{
+		System: asset.System,
+		Fields: EntryFields{"title": title, "file": file},
+	}
+}
+
+// decodeLinked decodes a linked entry into a string, holding its identifier,
+// or a struct
+func (d *Decoder) decodeLinked(entry *Entry, field reflect.Value, depth int) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(entry.ID)
+		return nil
+	case reflect.Struct:
+		if depth >= d.maxDepth() {
+			return nil
+		}
+
+		return d.decodeEntry(entry, field, depth+1)
+	}
+
+	return mismatch(entry, field)
+}
+
+// decodeJSON decodes objects using their json representation
+func (d *Decoder) decodeJSON(value interface{}, field reflect.Value) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, field.Addr().Interface())
+}
+
+// include returns the entry or asset the link points to from the decoder's
+// includes
+func (d *Decoder) include(link *LinkData) interface{} {
+	if d.Includes == nil {
+		return nil
+	}
+
+	switch link.LinkType {
+	case "Entry":
+		for _, entry := range d.Includes.Entries {
+			if entry.ID == link.ID {
+				return entry
+			}
+		}
+	case "Asset":
+		for _, asset := range d.Includes.Assets {
+			if asset.ID == link.ID {
+				return asset
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Decoder) maxDepth() int {
+	if d.MaxDepth <= 0 {
+		return defaultMaxDepth
+	}
+
+	return d.MaxDepth
+}
+
+func mismatch(value interface{}, field reflect.Value) error {
+	return fmt.Errorf("cannot decode %T into %v", value, field.Type())
+}
+
+// taggedField is a struct field mapped to an entry field
+type taggedField struct {
+	index int
+	name  string
+
+	omitEmpty bool
+	// linkType is set for fields that are encoded as links
+	linkType string
+}
+
+// structFields returns the exported fields of the struct with a contentful tag
+func structFields(t reflect.Type) []taggedField {
+	fields := []taggedField{}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("contentful")
+		if !ok || tag == "-" || sf.PkgPath != "" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		field := taggedField{index: i, name: parts[0]}
+
+		for _, option := range parts[1:] {
+			switch option {
+			case "omitempty":
+				field.omitEmpty = true
+			case "link":
+				field.linkType = "Entry"
+			case "asset":
+				field.linkType = "Asset"
+			}
+		}
+
+		if field.name == "" {
+			field.name = sf.Name
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// EncodeEntry encodes the fields of v, a struct or pointer to a struct tagged
+// like for the Decoder, into entry fields for the given locale. The result can
+// be used for CreateEntry or merged into an existing entry with MergeFields
+// before calling UpdateEntry.
+//
+// time.Time values are encoded as ISO8601 dates and *Entry or *Asset values
+// as links. String fields, slices of strings and structs holding the identifier
+// of a linked resource in a sys.id field are encoded as links to entries when
+// tagged with the link option, or links to assets with the asset option:
+//
+//	type Post struct {
+//		Title    string   `contentful:"title"`
+//		AuthorID string   `contentful:"author,link"`
+//		ImageIDs []string `contentful:"images,asset"`
+//		Summary  string   `contentful:"summary,omitempty"`
+//	}
+//
+// Nil pointers, links with an empty identifier and fields tagged with omitempty
+// that have the zero value are left out. System properties are never encoded.
+func EncodeEntry(v interface{}, locale string) (EntryFields, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("EncodeEntry failed. Value must be a struct, got %T", v)
+	}
+
+	if locale == "" {
+		return nil, fmt.Errorf("EncodeEntry failed. Locale cannot be empty!")
+	}
+
+	fields := EntryFields{}
+	for _, f := range structFields(rv.Type()) {
+		if strings.HasPrefix(f.name, "sys.") {
+			continue
+		}
+
+		field := rv.Field(f.index)
+		if f.omitEmpty && isEmptyValue(field) {
+			continue
+		}
+
+		value, ok, err := encodeValue(field, f.linkType)
+		if err != nil {
+			return nil, fmt.Errorf("EncodeEntry failed. Field %v: %v", f.name, err)
+		}
+
+		if ok {
+			fields[f.name] = map[string]interface{}{locale: value}
+		}
+	}
+
+	return fields, nil
+}
+
+// MergeFields sets the localized values of fields on the entry, leaving other
+// fields and locales untouched.
+func (c *Entry) MergeFields(fields EntryFields) {
+	if c.Fields == nil {
+		c.Fields = EntryFields{}
+	}
+
+	for id, value := range fields {
+		locales, ok := value.(map[string]interface{})
+		existing, exists := c.Fields[id].(map[string]interface{})
+		if !ok || !exists {
+			c.Fields[id] = value
+			continue
+		}
+
+		for locale, localized := range locales {
+			existing[locale] = localized
+		}
+	}
+}
+
+// encodeValue returns the JSON compatible representation of the value and
+// whether it should be included
+func encodeValue(rv reflect.Value, linkType string) (interface{}, bool, error) {
+	if rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, false, nil
+		}
+
+		switch v := rv.Interface().(type) {
+		case *Entry:
+			return v.Link(), true, nil
+		case *Asset:
+			return v.Link(), true, nil
+		}
+
+		return encodeValue(rv.Elem(), linkType)
+	}
+
+	if rv.Type() == timeType {
+		t := rv.Interface().(time.Time)
+		if t.IsZero() {
+			return nil, false, nil
+		}
+
+		return t.Format(time.RFC3339), true, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		if linkType != "" {
+			// Unset links are left out, Contentful rejects links without an ID
+			if rv.Len() == 0 {
+				return nil, false, nil
+			}
+
+			return newLink(linkType, rv.String()), true, nil
+		}
+	case reflect.Struct:
+		if id, ok := sysID(rv); ok {
+			if id == "" {
+				return nil, false, nil
+			}
+
+			if linkType == "" {
+				linkType = "Entry"
+			}
+
+			return newLink(linkType, id), true, nil
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil, false, nil
+		}
+
+		items := make([]interface{}, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item, ok, err := encodeValue(rv.Index(i), linkType)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if ok {
+				items = append(items, item)
+			}
+		}
+
+		return items, true, nil
+	case reflect.Func, reflect.Chan, reflect.Complex64, reflect.Complex128:
+		return nil, false, fmt.Errorf("cannot encode %v", rv.Type())
+	}
+
+	return rv.Interface(), true, nil
+}
+
+// sysID returns the value of the field tagged with sys.id
+func sysID(rv reflect.Value) (string, bool) {
+	for _, f := range structFields(rv.Type()) {
+		if f.name == "sys.id" && rv.Field(f.index).Kind() == reflect.String {
+			return rv.Field(f.index).String(), true
+		}
+	}
+
+	return "", false
+}
+
+func newLink(linkType string, id string) map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"sys": map[string]interface{}{
+			"id":       id,
+			"linkType": linkType,
+			"type":     LinkType,
+		},
+	}
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface().(time.Time).IsZero()
+		}
+	}
+
+	return false
+}
@@ -11,6 +11,9 @@ type Linkable interface {
 type EntryFields map[string]interface{}
 
 type NewEntry struct {
+	// ID is the identifier of the created entry. It is generated by Contentful
+	// if empty.
+	ID     string      `json:"-"`
 	Fields EntryFields `json:"fields"`
 }
 
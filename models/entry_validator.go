@@ -0,0 +1,578 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Maximum lengths of text fields
+const (
+	maxShortTextLength = 256
+	maxLongTextLength  = 50000
+)
+
+// mimeTypeGroups maps the groups of the linkMimetypeGroup validation to MIME
+// type prefixes
+var mimeTypeGroups = map[string][]string{
+	"attachment":   {""},
+	"plaintext":    {"text/plain"},
+	"image":        {"image/"},
+	"audio":        {"audio/"},
+	"video":        {"video/"},
+	"richtext":     {"text/rtf", "application/rtf", "application/msword", "application/vnd.openxmlformats-officedocument.wordprocessingml", "application/vnd.oasis.opendocument.text"},
+	"presentation": {"application/vnd.ms-powerpoint", "application/vnd.openxmlformats-officedocument.presentationml", "application/vnd.oasis.opendocument.presentation"},
+	"spreadsheet":  {"application/vnd.ms-excel", "application/vnd.openxmlformats-officedocument.spreadsheetml", "application/vnd.oasis.opendocument.spreadsheet", "text/csv"},
+	"pdfdocument":  {"application/pdf"},
+	"archive":      {"application/zip", "application/x-zip", "application/x-tar", "application/gzip", "application/x-gzip", "application/x-7z-compressed", "application/x-rar"},
+	"code":         {"application/json", "application/javascript", "application/xml", "text/javascript", "text/css", "text/x-"},
+	"markup":       {"text/html", "application/xhtml+xml", "text/xml", "text/markdown"},
+}
+
+// FieldError is a problem with the value of a field in a locale
+type FieldError struct {
+	Field  string
+	Locale string
+	// Validation is the kind of check that failed, for example "required",
+	// "type", "size" or "linkContentType"
+	Validation string
+	// Message is the message of the field validation, or a description of the
+	// problem if the validation has none
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if e.Locale == "" {
+		return fmt.Sprintf("%v: %v", e.Field, e.Message)
+	}
+
+	return fmt.Sprintf("%v (%v): %v", e.Field, e.Locale, e.Message)
+}
+
+// FieldErrors are all problems found when validating an entry. It can be
+// matched with errors.Is(err, ErrValidationFailed).
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return fmt.Sprintf("Entry validation failed. %v", strings.Join(messages, "; "))
+}
+
+// Is reports whether target is ErrValidationFailed
+func (e FieldErrors) Is(target error) bool {
+	return target == ErrValidationFailed
+}
+
+// EntryValidator checks entry fields against the fields and validations of a
+// content type before they are sent to Contentful:
+//
+//	validator := NewEntryValidator(contentType, locales)
+//	if err := validator.ValidateEntry(entry); err != nil {
+//		for _, fieldErr := range err.(FieldErrors) {
+//			...
+//		}
+//	}
+//
+// Link validations need the linked entries and assets. Links that have been
+// resolved, see QueryEntriesResult.ResolveLinks, or can be found in Includes
+// are checked, other links are skipped.
+type EntryValidator struct {
+	ContentType *ContentType
+	// Locales are the locales of the space. Required fields must have a value
+	// for the default locale and every locale that is not optional. Without
+	// locales required fields must have a value for at least one locale.
+	Locales []*Locale
+
+	// Includes are used to look up linked entries and assets
+	Includes *Includes
+}
+
+// NewEntryValidator creates a validator for entries of the content type
+func NewEntryValidator(contentType *ContentType, locales []*Locale) *EntryValidator {
+	return &EntryValidator{
+		ContentType: contentType,
+		Locales:     locales,
+	}
+}
+
+// ValidateEntry validates the fields of the entry and checks that it belongs to
+// the validator's content type. FieldErrors are returned if the entry is not
+// valid.
+func (v *EntryValidator) ValidateEntry(entry *Entry) error {
+	if entry == nil {
+		return fmt.Errorf("ValidateEntry failed. Entry cannot be nil!")
+	}
+
+	if entry.ContentType != nil && entry.ContentType.LinkData != nil && entry.ContentType.ID != v.ContentType.ID {
+		return FieldErrors{{
+			Field:      "sys.contentType",
+			Validation: "type",
+			Message:    fmt.Sprintf("entry has content type %v, expected %v", entry.ContentType.ID, v.ContentType.ID),
+		}}
+	}
+
+	return v.Validate(entry.Fields)
+}
+
+// Validate validates the fields, for example those of a NewEntry. FieldErrors
+// are returned if the fields are not valid.
+func (v *EntryValidator) Validate(fields EntryFields) error {
+	if v.ContentType == nil {
+		return fmt.Errorf("Validate failed. ContentType cannot be nil!")
+	}
+
+	errs := FieldErrors{}
+	add := func(field string, locale string, validation string, message string) {
+		errs = append(errs, &FieldError{Field: field, Locale: locale, Validation: validation, Message: message})
+	}
+
+	known := map[string]bool{}
+	for _, field := range v.ContentType.Fields {
+		known[field.ID] = true
+		if field.Disabled {
+			continue
+		}
+
+		value, present := fields[field.ID]
+		locales, ok := value.(map[string]interface{})
+		if present && value != nil && !ok {
+			add(field.ID, "", "localized", "value must be an object keyed by locale")
+			continue
+		}
+
+		for _, locale := range sortedKeys(locales) {
+			localized := locales[locale]
+			if !v.knownLocale(locale) {
+				add(field.ID, locale, "locale", fmt.Sprintf("unknown locale %v", locale))
+				continue
+			}
+
+			if !field.Localized && v.defaultLocale() != "" && locale != v.defaultLocale() {
+				add(field.ID, locale, "localized", fmt.Sprintf("field is not localized, only %v is allowed", v.defaultLocale()))
+				continue
+			}
+
+			if localized == nil {
+				continue
+			}
+
+			for _, problem := range v.validateValue(field, locale, localized) {
+				add(field.ID, locale, problem.Validation, problem.Message)
+			}
+		}
+
+		if field.Required {
+			for _, locale := range v.requiredLocales(field) {
+				if locales[locale] == nil {
+					add(field.ID, locale, "required", "value is required")
+				}
+			}
+
+			if len(v.Locales) == 0 && len(locales) == 0 {
+				add(field.ID, "", "required", "value is required")
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(fields) {
+		if !known[id] {
+			add(id, "", "unknown", fmt.Sprintf("content type %v has no field %v", v.ContentType.ID, id))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func (v *EntryValidator) defaultLocale() string {
+	for _, locale := range v.Locales {
+		if locale.Default {
+			return locale.Code
+		}
+	}
+
+	return ""
+}
+
+func (v *EntryValidator) knownLocale(code string) bool {
+	if len(v.Locales) == 0 {
+		return true
+	}
+
+	for _, locale := range v.Locales {
+		if locale.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requiredLocales returns the locales a required field needs a value for
+func (v *EntryValidator) requiredLocales(field Field) []string {
+	locales := []string{}
+	for _, locale := range v.Locales {
+		if locale.Default || (field.Localized && !locale.Optional) {
+			locales = append(locales, locale.Code)
+		}
+	}
+
+	return locales
+}
+
+// validateValue checks the type and validations of a single value
+func (v *EntryValidator) validateValue(field Field, locale string, value interface{}) []*FieldError {
+	if problem := checkType(field, value); problem != "" {
+		return []*FieldError{{Validation: "type", Message: problem}}
+	}
+
+	problems := []*FieldError{}
+	for _, validation := range field.Validations {
+		if kind, failed := v.check(field, locale, validation, value); failed {
+			message := validation.Message
+			if message == "" {
+				message = fmt.Sprintf("%v validation failed", kind)
+			}
+
+			problems = append(problems, &FieldError{Validation: kind, Message: message})
+		}
+	}
+
+	if field.Type == Array && field.Items != nil {
+		items := *field.Items
+		items.ID = field.ID
+
+		for i, item := range value.([]interface{}) {
+			for _, problem := range v.validateValue(items, locale, item) {
+				problem.Message = fmt.Sprintf("item %v: %v", i, problem.Message)
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkType returns a description of the problem if the value does not match
+// the type of the field
+func checkType(field Field, value interface{}) string {
+	switch field.Type {
+	case ShortText, LongText:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected text, got %T", value)
+		}
+
+		max := maxShortTextLength
+		if field.Type == LongText {
+			max = maxLongTextLength
+		}
+
+		if utf8.RuneCountInString(s) > max {
+			return fmt.Sprintf("text must not be longer than %v characters", max)
+		}
+	case Integer:
+		n, ok := number(value)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Sprintf("expected integer, got %v", value)
+		}
+	case Number:
+		if _, ok := number(value); !ok {
+			return fmt.Sprintf("expected number, got %T", value)
+		}
+	case Boolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("expected boolean, got %T", value)
+		}
+	case Date:
+		if _, ok := date(value); !ok {
+			return fmt.Sprintf("expected ISO8601 date, got %v", value)
+		}
+	case Location:
+		switch l := value.(type) {
+		case GeoPoint, *GeoPoint:
+		case map[string]interface{}:
+			_, lat := number(l["lat"])
+			_, lon := number(l["lon"])
+			if !lat || !lon {
+				return "expected location with lat and lon"
+			}
+		default:
+			return fmt.Sprintf("expected location, got %T", value)
+		}
+	case Object:
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+		default:
+			return fmt.Sprintf("expected object, got %T", value)
+		}
+	case LinkType:
+		linkType, ok := linkTypeOf(value)
+		if !ok {
+			return fmt.Sprintf("expected link, got %T", value)
+		}
+
+		if field.LinkType != "" && linkType != field.LinkType {
+			return fmt.Sprintf("expected link to %v, got link to %v", field.LinkType, linkType)
+		}
+	case Array:
+		if _, ok := value.([]interface{}); !ok {
+			return fmt.Sprintf("expected array, got %T", value)
+		}
+	}
+
+	return ""
+}
+
+// check applies the validation to the value and returns its kind and whether
+// it failed
+func (v *EntryValidator) check(field Field, locale string, validation FieldValidation, value interface{}) (string, bool) {
+	switch {
+	case validation.Size != nil:
+		size := 0
+		switch x := value.(type) {
+		case string:
+			size = utf8.RuneCountInString(x)
+		case []interface{}:
+			size = len(x)
+		}
+
+		return "size", outsideSize(float64(size), validation.Size)
+	case validation.Range != nil:
+		n, _ := number(value)
+		tooSmall := validation.Range.Min != nil && n < float64(*validation.Range.Min)
+		tooLarge := validation.Range.Max != nil && n > float64(*validation.Range.Max)
+
+		return "range", tooSmall || tooLarge
+	case validation.DateRange != nil:
+		t, _ := date(value)
+		tooEarly := validation.DateRange.Min != nil && t.Before(*validation.DateRange.Min)
+		tooLate := validation.DateRange.Max != nil && t.After(*validation.DateRange.Max)
+
+		return "dateRange", tooEarly || tooLate
+	case validation.RegularExpression != nil:
+		s, ok := value.(string)
+		if !ok {
+			return "regexp", false
+		}
+
+		re, err := regexp.Compile(validation.RegularExpression.Pattern)
+		return "regexp", err != nil || !re.MatchString(s)
+	case len(validation.In) > 0:
+		for _, allowed := range validation.In {
+			if equalValues(allowed, value) {
+				return "in", false
+			}
+		}
+
+		return "in", true
+	case len(validation.LinkContentTypes) > 0:
+		entry := v.linkedEntry(value)
+		if entry == nil || entry.ContentType == nil || entry.ContentType.LinkData == nil {
+			return "linkContentType", false
+		}
+
+		for _, id := range validation.LinkContentTypes {
+			if entry.ContentType.ID == id {
+				return "linkContentType", false
+			}
+		}
+
+		return "linkContentType", true
+	case len(validation.LinkMIMETypeGroup) > 0:
+		file, ok := v.linkedFile(value, locale)
+		if !ok {
+			return "linkMimetypeGroup", false
+		}
+
+		for _, group := range validation.LinkMIMETypeGroup {
+			for _, prefix := range mimeTypeGroups[group] {
+				if strings.HasPrefix(file.MIMEType, prefix) {
+					return "linkMimetypeGroup", false
+				}
+			}
+		}
+
+		return "linkMimetypeGroup", true
+	case validation.AssetImageValidation != nil:
+		file, ok := v.linkedFile(value, locale)
+		if !ok || file.Detail == nil || file.Detail.Image == nil {
+			return "assetImageDimensions", false
+		}
+
+		dimensions := validation.AssetImageValidation
+		image := file.Detail.Image
+		tooWide := dimensions.Width != nil && outsideSize(float64(image.Width), dimensions.Width)
+		tooHigh := dimensions.Height != nil && outsideSize(float64(image.Height), dimensions.Height)
+
+		return "assetImageDimensions", tooWide || tooHigh
+	}
+
+	return "", false
+}
+
+// linkedEntry returns the entry the value links to, if it is known
+func (v *EntryValidator) linkedEntry(value interface{}) *Entry {
+	if entry, ok := value.(*Entry); ok {
+		return entry
+	}
+
+	if x, ok := linkObject(value); ok {
+		link, ok := linkData(x)
+		if !ok || link.LinkType != "Entry" || v.Includes == nil {
+			return nil
+		}
+
+		for _, entry := range v.Includes.Entries {
+			if entry.ID == link.ID {
+				return entry
+			}
+		}
+	}
+
+	return nil
+}
+
+// linkedFile returns the file of the asset the value links to in the locale,
+// or any locale if the asset has no file for it
+func (v *EntryValidator) linkedFile(value interface{}, locale string) (AssetData, bool) {
+	asset, _ := value.(*Asset)
+
+	if x, ok := linkObject(value); ok {
+		link, ok := linkData(x)
+		if !ok || link.LinkType != "Asset" || v.Includes == nil {
+			return AssetData{}, false
+		}
+
+		for _, included := range v.Includes.Assets {
+			if included.ID == link.ID {
+				asset = included
+			}
+		}
+	}
+
+	if asset == nil {
+		return AssetData{}, false
+	}
+
+	if file, ok := asset.Fields.File[locale]; ok {
+		return file, true
+	}
+
+	for _, file := range asset.Fields.File {
+		return file, true
+	}
+
+	return AssetData{}, false
+}
+
+// outsideSize reports whether n is outside of the bounds of the validation
+func outsideSize(n float64, size *SizeFieldValidation) bool {
+	return (size.Min != nil && n < *size.Min) || (size.Max != nil && n > *size.Max)
+}
+
+func number(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	}
+
+	return 0, false
+}
+
+func date(value interface{}) (time.Time, bool) {
+	switch d := value.(type) {
+	case time.Time:
+		return d, true
+	case string:
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, d); err == nil {
+				return t, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func linkTypeOf(value interface{}) (string, bool) {
+	switch value.(type) {
+	case *Entry:
+		return "Entry", true
+	case *Asset:
+		return "Asset", true
+	}
+
+	if x, ok := linkObject(value); ok {
+		if link, ok := linkData(x); ok {
+			return link.LinkType, true
+		}
+	}
+
+	return "", false
+}
+
+func equalValues(a interface{}, b interface{}) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		return ok && x == y
+	case bool:
+		y, ok := b.(bool)
+		return ok && x == y
+	}
+
+	return false
+}
+
+// linkObject returns links created with the Link method of entries and assets
+// in the same form as decoded links
+func linkObject(value interface{}) (map[string]interface{}, bool) {
+	switch x := value.(type) {
+	case map[string]interface{}:
+		return x, true
+	case map[string]map[string]interface{}:
+		object := map[string]interface{}{}
+		for k, v := range x {
+			object[k] = v
+		}
+
+		return object, true
+	}
+
+	return nil, false
+}
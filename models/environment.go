@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+)
+
+// EnvironmentReady is the status of an environment that finished copying the
+// content of its source environment
+const EnvironmentReady = "ready"
+
+// Environment is an isolated copy of the content types, entries, assets and
+// locales of a space. Every space has a master environment, additional
+// environments are typically used for development, staging or migrations.
+type Environment struct {
+	System `json:"sys"`
+	Name   string `json:"name"`
+}
+
+// Validate will validate the environment. An error is returned if the
+// environment is not valid.
+func (e *Environment) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("Environment must specify a valid name")
+	}
+
+	return nil
+}
+
+// Ready reports whether the environment can be used. Newly created environments
+// are not ready until the content of the source environment has been copied.
+func (e *Environment) Ready() bool {
+	return e.Status != nil && e.Status.LinkData != nil && e.Status.ID == EnvironmentReady
+}
+
+// Link returns a link to the environment
+func (e *Environment) Link() *Link {
+	return &Link{
+		LinkData: &LinkData{
+			Type:     LinkType,
+			LinkType: "Environment",
+			ID:       e.ID,
+		},
+	}
+}
+
+// EnvironmentAlias is a stable identifier that points to an environment.
+// Clients can read content through the alias while the environment it targets
+// is swapped, for example to release a migrated environment as master.
+type EnvironmentAlias struct {
+	System      `json:"sys"`
+	Environment *Link `json:"environment"`
+}
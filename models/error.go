@@ -1,6 +1,55 @@
 package models
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Errors returned by the Contentful APIs can be matched against these values
+// using errors.Is:
+//
+//	if errors.Is(err, ErrVersionMismatch) {
+//		// fetch the latest version and try again
+//	}
+var (
+	ErrNotFound           = errors.New("NotFound")
+	ErrVersionMismatch    = errors.New("VersionMismatch")
+	ErrValidationFailed   = errors.New("ValidationFailed")
+	ErrRateLimitExceeded  = errors.New("RateLimitExceeded")
+	ErrAccessTokenInvalid = errors.New("AccessTokenInvalid")
+	ErrAccessDenied       = errors.New("AccessDenied")
+	ErrBadRequest         = errors.New("BadRequest")
+	ErrServerError        = errors.New("ServerError")
+)
+
+// errorIDs maps the sys.id of Contentful errors to their sentinel error
+var errorIDs = map[string]error{
+	"NotFound":           ErrNotFound,
+	"VersionMismatch":    ErrVersionMismatch,
+	"ValidationFailed":   ErrValidationFailed,
+	"InvalidEntry":       ErrValidationFailed,
+	"RateLimitExceeded":  ErrRateLimitExceeded,
+	"AccessTokenInvalid": ErrAccessTokenInvalid,
+	"AccessDenied":       ErrAccessDenied,
+	"BadRequest":         ErrBadRequest,
+	"InvalidQuery":       ErrBadRequest,
+	"ServerError":        ErrServerError,
+}
+
+// errorStatusCodes maps HTTP status codes to their sentinel error for errors
+// without a known sys.id
+var errorStatusCodes = map[int]error{
+	http.StatusBadRequest:          ErrBadRequest,
+	http.StatusUnauthorized:        ErrAccessTokenInvalid,
+	http.StatusForbidden:           ErrAccessDenied,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrVersionMismatch,
+	http.StatusUnprocessableEntity: ErrValidationFailed,
+	http.StatusTooManyRequests:     ErrRateLimitExceeded,
+}
 
 // Error represnts the error object that is returned when something
 // goes wrong with a Contentful API request. This struct conforms to the `error`
@@ -16,8 +65,134 @@ type Error struct {
 	Details struct {
 		Errors []interface{} `json:"errors"`
 	} `json:"details"`
+
+	// StatusCode is the HTTP status code of the response
+	StatusCode int `json:"-"`
+	// RateLimitReset is how long to wait before the rate limit resets. It is
+	// only set when Contentful reported it.
+	RateLimitReset time.Duration `json:"-"`
 }
 
 func (e Error) Error() string {
-	return fmt.Sprintf("%v, %v, %v", e.Message, e.RequestID, e.Sys)
+	message := e.Message
+	if e.Sys.ID != "" {
+		if message == "" {
+			message = e.Sys.ID
+		} else {
+			message = fmt.Sprintf("%v: %v", e.Sys.ID, message)
+		}
+	}
+
+	if e.RequestID != "" {
+		message = fmt.Sprintf("%v (request ID: %v)", message, e.RequestID)
+	}
+
+	return message
+}
+
+// Is reports whether the error matches one of the sentinel errors such as
+// ErrNotFound.
+func (e Error) Is(target error) bool {
+	if err, ok := errorIDs[e.Sys.ID]; ok {
+		return err == target
+	}
+
+	if e.StatusCode >= http.StatusInternalServerError {
+		return target == ErrServerError
+	}
+
+	if err, ok := errorStatusCodes[e.StatusCode]; ok {
+		return err == target
+	}
+
+	return false
+}
+
+// ValidationErrors decodes Details.Errors into structured validation problems.
+// Errors that cannot be decoded are skipped.
+func (e Error) ValidationErrors() []*ValidationError {
+	validationErrors := []*ValidationError{}
+
+	for _, detail := range e.Details.Errors {
+		data, err := json.Marshal(detail)
+		if err != nil {
+			continue
+		}
+
+		validationError := new(ValidationError)
+		if err = json.Unmarshal(data, validationError); err != nil {
+			continue
+		}
+
+		validationErrors = append(validationErrors, validationError)
+	}
+
+	return validationErrors
+}
+
+// ValidationError is a single validation problem reported by Contentful when
+// an entry or asset violates its content type.
+type ValidationError struct {
+	// Name is the kind of validation that failed, for example "required",
+	// "size" or "in"
+	Name string `json:"name"`
+	// Path points at the offending value, for example ["fields", "title", "en-US"]
+	Path    []interface{} `json:"path"`
+	Details string        `json:"details"`
+
+	Value    interface{}   `json:"value,omitempty"`
+	Expected []interface{} `json:"expected,omitempty"`
+	Min      interface{}   `json:"min,omitempty"`
+	Max      interface{}   `json:"max,omitempty"`
+}
+
+// Field returns the identifier of the field the problem was reported for
+func (v *ValidationError) Field() string {
+	return v.pathElement(1)
+}
+
+// Locale returns the locale code the problem was reported for
+func (v *ValidationError) Locale() string {
+	return v.pathElement(2)
+}
+
+func (v *ValidationError) pathElement(index int) string {
+	if len(v.Path) <= index || v.Path[0] != "fields" {
+		return ""
+	}
+
+	element, _ := v.Path[index].(string)
+	return element
+}
+
+func (v *ValidationError) Error() string {
+	if v.Details != "" {
+		return v.Details
+	}
+
+	return fmt.Sprintf("%v validation failed for %v", v.Name, v.Path)
+}
+
+// ContentError is an error reported for a single item of an entries query,
+// such as a link that cannot be resolved. The Delivery API returns them in the
+// errors of the response, QueryEntriesResult.ResolveLinks reports links that
+// aren't part of the includes the same way.
+type ContentError struct {
+	Details struct {
+		ID       string `json:"id"`
+		Type     string `json:"type"`
+		LinkType string `json:"linkType"`
+	} `json:"details"`
+	Sys struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	} `json:"sys"`
+}
+
+func (e *ContentError) Error() string {
+	if e.Details.ID == "" {
+		return fmt.Sprintf("Error: %v", e.Sys.ID)
+	}
+
+	return fmt.Sprintf("Error: %v %v %v", e.Sys.ID, e.Details.LinkType, e.Details.ID)
 }
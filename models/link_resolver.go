@@ -0,0 +1,160 @@
+package models
+
+// ResolveLinks replaces the links inside the fields of the result entries with
+// pointers to the matching *Entry or *Asset found in Entries or Includes. Links
+// of resolved entries are followed up to the given depth, which mirrors the
+// include parameter of the query: a depth of 1 only resolves the links of the
+// top-level entries.
+//
+// Fields are walked recursively so single links, arrays of links and
+// localized maps are all resolved. Every entry is only walked once, entries
+// that link to each other will therefore end up pointing at each other rather
+// than being resolved endlessly. Results with such cycles cannot be serialized,
+// json.Marshal recurses until the stack overflows. Marshal the result before
+// resolving its links if it has to be serialized.
+//
+// Links that could not be resolved are left in place and returned. They are
+// also added to Errors as notResolvable ContentErrors, unless the API already
+// reported them.
+func (r *QueryEntriesResult) ResolveLinks(depth int) (unresolved []*LinkData) {
+	resolver := &linkResolver{
+		entries: map[string]*Entry{},
+		assets:  map[string]*Asset{},
+		walked:  map[*Entry]bool{},
+		missing: map[string]bool{},
+	}
+
+	for _, entry := range r.Entries {
+		resolver.entries[entry.ID] = entry
+	}
+
+	if r.Includes != nil {
+		for _, entry := range r.Includes.Entries {
+			resolver.entries[entry.ID] = entry
+		}
+
+		for _, asset := range r.Includes.Assets {
+			resolver.assets[asset.ID] = asset
+		}
+	}
+
+	level := r.Entries
+	for i := 0; i < depth && len(level) > 0; i++ {
+		resolver.next = []*Entry{}
+
+		for _, entry := range level {
+			if entry == nil || resolver.walked[entry] {
+				continue
+			}
+
+			resolver.walked[entry] = true
+			for id, value := range entry.Fields {
+				entry.Fields[id] = resolver.resolve(value)
+			}
+		}
+
+		level = resolver.next
+	}
+
+	r.reportUnresolved(resolver.unresolved)
+
+	return resolver.unresolved
+}
+
+// reportUnresolved adds a notResolvable ContentError for every link that
+// hasn't been reported yet
+func (r *QueryEntriesResult) reportUnresolved(links []*LinkData) {
+	reported := map[string]bool{}
+	for _, err := range r.Errors {
+		if contentError, ok := err.(*ContentError); ok {
+			reported[contentError.Details.LinkType+":"+contentError.Details.ID] = true
+		}
+	}
+
+	for _, link := range links {
+		if reported[link.LinkType+":"+link.ID] {
+			continue
+		}
+
+		contentError := new(ContentError)
+		contentError.Sys.Type = "error"
+		contentError.Sys.ID = "notResolvable"
+		contentError.Details.Type = link.Type
+		contentError.Details.LinkType = link.LinkType
+		contentError.Details.ID = link.ID
+
+		r.Errors = append(r.Errors, contentError)
+	}
+}
+
+type linkResolver struct {
+	entries map[string]*Entry
+	assets  map[string]*Asset
+
+	// walked contains every entry whose fields have been resolved
+	walked map[*Entry]bool
+	// next contains the entries that were linked during the current level
+	next []*Entry
+
+	missing    map[string]bool
+	unresolved []*LinkData
+}
+
+func (r *linkResolver) resolve(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if link, ok := linkData(v); ok {
+			return r.lookup(link, v)
+		}
+
+		for k, child := range v {
+			v[k] = r.resolve(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = r.resolve(child)
+		}
+	case *Entry:
+		r.next = append(r.next, v)
+	}
+
+	return value
+}
+
+// lookup returns the linked entry or asset. The original link is returned if
+// the link cannot be resolved.
+func (r *linkResolver) lookup(link *LinkData, original interface{}) interface{} {
+	switch link.LinkType {
+	case "Entry":
+		if entry, ok := r.entries[link.ID]; ok {
+			r.next = append(r.next, entry)
+			return entry
+		}
+	case "Asset":
+		if asset, ok := r.assets[link.ID]; ok {
+			return asset
+		}
+	default:
+		return original
+	}
+
+	if key := link.LinkType + ":" + link.ID; !r.missing[key] {
+		r.missing[key] = true
+		r.unresolved = append(r.unresolved, link)
+	}
+
+	return original
+}
+
+// linkData returns the link information if the decoded JSON object is a link
+func linkData(v map[string]interface{}) (*LinkData, bool) {
+	sys, ok := v["sys"].(map[string]interface{})
+	if !ok || sys["type"] != LinkType {
+		return nil, false
+	}
+
+	id, _ := sys["id"].(string)
+	linkType, _ := sys["linkType"].(string)
+
+	return &LinkData{Type: LinkType, LinkType: linkType, ID: id}, true
+}
@@ -0,0 +1,236 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Query builds the search parameters for entry and asset queries. Field
+// arguments are the full attribute paths understood by Contentful, such as
+// "sys.id" or "fields.slug". Nil *time.Time values are skipped, filters
+// without other values are left out. Every method returns the query so calls
+// can be chained:
+//
+//	query := NewQuery().
+//		ContentType("post").
+//		In("fields.slug", "hello", "world").
+//		Order("-sys.createdAt").
+//		Include(2)
+type Query struct {
+	values url.Values
+	order  []string
+}
+
+// NewQuery returns an empty query
+func NewQuery() *Query {
+	return &Query{values: url.Values{}}
+}
+
+// NewQueryFromParams returns a query containing the provided raw parameters
+func NewQueryFromParams(params map[string]string) *Query {
+	q := NewQuery()
+	for k, v := range params {
+		q.Param(k, v)
+	}
+
+	return q
+}
+
+// Param adds a raw query parameter. Parameters can be added more than once.
+func (q *Query) Param(key string, value string) *Query {
+	q.values.Add(key, value)
+	return q
+}
+
+// ContentType restricts the query to entries of the given content type. It is
+// required when querying on fields.
+func (q *Query) ContentType(contentTypeID string) *Query {
+	q.values.Set("content_type", contentTypeID)
+	return q
+}
+
+// Equal matches items where the field equals the value
+func (q *Query) Equal(field string, value interface{}) *Query {
+	formatted, ok := queryValue(value)
+	if !ok {
+		return q
+	}
+
+	return q.Param(field, formatted)
+}
+
+// NotEqual matches items where the field does not equal the value
+func (q *Query) NotEqual(field string, value interface{}) *Query {
+	return q.operator(field, "ne", value)
+}
+
+// All matches items where the array field contains all of the values
+func (q *Query) All(field string, values ...interface{}) *Query {
+	return q.operator(field, "all", values...)
+}
+
+// In matches items where the field equals any of the values
+func (q *Query) In(field string, values ...interface{}) *Query {
+	return q.operator(field, "in", values...)
+}
+
+// NotIn matches items where the field equals none of the values
+func (q *Query) NotIn(field string, values ...interface{}) *Query {
+	return q.operator(field, "nin", values...)
+}
+
+// Exists matches items where the field is either set or not set
+func (q *Query) Exists(field string, exists bool) *Query {
+	return q.operator(field, "exists", exists)
+}
+
+// LessThan matches items where the field is less than the value
+func (q *Query) LessThan(field string, value interface{}) *Query {
+	return q.operator(field, "lt", value)
+}
+
+// LessThanOrEqual matches items where the field is less than or equal to the value
+func (q *Query) LessThanOrEqual(field string, value interface{}) *Query {
+	return q.operator(field, "lte", value)
+}
+
+// GreaterThan matches items where the field is greater than the value
+func (q *Query) GreaterThan(field string, value interface{}) *Query {
+	return q.operator(field, "gt", value)
+}
+
+// GreaterThanOrEqual matches items where the field is greater than or equal to
+// the value
+func (q *Query) GreaterThanOrEqual(field string, value interface{}) *Query {
+	return q.operator(field, "gte", value)
+}
+
+// Match performs a full text search on the field
+func (q *Query) Match(field string, text string) *Query {
+	return q.operator(field, "match", text)
+}
+
+// FullText performs a full text search across all text and symbol fields
+func (q *Query) FullText(text string) *Query {
+	q.values.Set("query", text)
+	return q
+}
+
+// Near orders the items by their distance to the coordinates. The field must
+// be a Location field.
+func (q *Query) Near(field string, lat float64, lon float64) *Query {
+	return q.operator(field, "near", lat, lon)
+}
+
+// Within matches items whose location lies within the rectangle described by
+// its bottom left and top right corners.
+func (q *Query) Within(field string, lat1 float64, lon1 float64, lat2 float64, lon2 float64) *Query {
+	return q.operator(field, "within", lat1, lon1, lat2, lon2)
+}
+
+// WithinRadius matches items whose location lies within the circle described
+// by its center and radius in kilometers.
+func (q *Query) WithinRadius(field string, lat float64, lon float64, radius float64) *Query {
+	return q.operator(field, "within", lat, lon, radius)
+}
+
+// Order sorts the items by the given fields. Prefix a field with "-" to
+// reverse the order. Subsequent calls add further order keys.
+func (q *Query) Order(fields ...string) *Query {
+	q.order = append(q.order, fields...)
+	return q
+}
+
+// OrderReversed sorts the items by the given field in reverse order
+func (q *Query) OrderReversed(field string) *Query {
+	return q.Order("-" + field)
+}
+
+// Select restricts the attributes returned for each item
+func (q *Query) Select(fields ...string) *Query {
+	q.values.Set("select", strings.Join(fields, ","))
+	return q
+}
+
+// Include sets the number of levels of linked entries and assets that are
+// included in the response.
+func (q *Query) Include(depth int) *Query {
+	q.values.Set("include", fmt.Sprintf("%v", depth))
+	return q
+}
+
+// LinksToEntry matches entries linking to the entry
+func (q *Query) LinksToEntry(entryID string) *Query {
+	q.values.Set("links_to_entry", entryID)
+	return q
+}
+
+// LinksToAsset matches entries linking to the asset
+func (q *Query) LinksToAsset(assetID string) *Query {
+	q.values.Set("links_to_asset", assetID)
+	return q
+}
+
+// MIMETypeGroup matches assets of the given group, for example "image" or
+// "pdfdocument".
+func (q *Query) MIMETypeGroup(group string) *Query {
+	q.values.Set("mimetype_group", group)
+	return q
+}
+
+// Locale restricts the returned fields to a single locale. Use "*" to
+// retrieve all locales.
+func (q *Query) Locale(code string) *Query {
+	q.values.Set("locale", code)
+	return q
+}
+
+// Values compiles the query into query parameters
+func (q *Query) Values() url.Values {
+	values := url.Values{}
+	for k, v := range q.values {
+		values[k] = append([]string{}, v...)
+	}
+
+	if len(q.order) > 0 {
+		values.Set("order", strings.Join(q.order, ","))
+	}
+
+	return values
+}
+
+// operator adds a filter with the values. Nil times are dropped, without any
+// values the filter is skipped.
+func (q *Query) operator(field string, operator string, values ...interface{}) *Query {
+	formatted := []string{}
+	for _, value := range values {
+		if v, ok := queryValue(value); ok {
+			formatted = append(formatted, v)
+		}
+	}
+
+	if len(formatted) == 0 {
+		return q
+	}
+
+	return q.Param(fmt.Sprintf("%v[%v]", field, operator), strings.Join(formatted, ","))
+}
+
+// queryValue formats a filter value. Nil times, such as the PublishedAt of a
+// draft, are reported as missing.
+func queryValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339), true
+	case *time.Time:
+		if v == nil {
+			return "", false
+		}
+
+		return v.Format(time.RFC3339), true
+	}
+
+	return fmt.Sprintf("%v", value), true
+}
@@ -17,12 +17,39 @@ type System struct {
 	Version int    `json:"version,omitempty"`
 
 	Space       *Link `json:"space,omitempty"`
+	Environment *Link `json:"environment,omitempty"`
 	ContentType *Link `json:"contentType,omitempty"`
 
+	// Status is only returned for environments and reports whether the
+	// environment is ready to be used
+	Status *Link `json:"status,omitempty"`
+
 	FirstPublished   *time.Time `json:"firstPublishedAt,omitempty"`
 	PublishedAt      *time.Time `json:"publishedAt,omitempty"`
 	PublishedVersion int        `json:"publishedVersion,omitempty"`
+	PublishedCounter int        `json:"publishedCounter,omitempty"`
 	ArchivedAt       *time.Time `json:"archivedAt,omitempty"`
+	ArchivedVersion  int        `json:"archivedVersion,omitempty"`
+
+	// Revision and DeletedAt are only returned by the Content Delivery API
+	Revision  int        `json:"revision,omitempty"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// IsPublished reports whether the resource has been published
+func (s System) IsPublished() bool {
+	return s.PublishedVersion > 0
+}
+
+// IsChanged reports whether the resource has been updated since it was last
+// published
+func (s System) IsChanged() bool {
+	return s.IsPublished() && s.Version > s.PublishedVersion+1
+}
+
+// IsArchived reports whether the resource has been archived
+func (s System) IsArchived() bool {
+	return s.ArchivedVersion > 0 || s.ArchivedAt != nil
 }
 
 // Link represents a link to another Contentful object
@@ -0,0 +1,30 @@
+package models
+
+// Sync item types
+const (
+	DeletedEntryType = "DeletedEntry"
+	DeletedAssetType = "DeletedAsset"
+)
+
+// DeletedItem is returned by the Sync API for every entry or asset that has
+// been deleted or unpublished since the previous sync. Only the system
+// metadata of the deleted resource is available.
+type DeletedItem struct {
+	System `json:"sys"`
+}
+
+// SyncResult is returned for initial and delta syncs. Entries and Assets
+// contain every item that was created or updated, DeletedEntries and
+// DeletedAssets contain the deletion records.
+//
+// NextSyncToken should be persisted and passed to the next delta sync in order
+// to only receive the changes that happened in the meantime.
+type SyncResult struct {
+	Entries        []*Entry
+	Assets         []*Asset
+	DeletedEntries []*DeletedItem
+	DeletedAssets  []*DeletedItem
+
+	NextSyncURL   string
+	NextSyncToken string
+}
@@ -0,0 +1,32 @@
+package models
+
+// Upload is a file uploaded through the Upload API. Uploads expire after 48
+// hours unless they are referenced by an asset through FileData.UploadFrom.
+type Upload struct {
+	System `json:"sys"`
+
+	// MIMEType is detected from the content of the upload, it is not returned
+	// by the API
+	MIMEType string `json:"-"`
+}
+
+// Link returns a link to the upload that can be used as FileData.UploadFrom
+func (u *Upload) Link() *Link {
+	return &Link{
+		LinkData: &LinkData{
+			Type:     LinkType,
+			LinkType: "Upload",
+			ID:       u.ID,
+		},
+	}
+}
+
+// FileData returns the file information of an asset file created from the
+// upload.
+func (u *Upload) FileData(fileName string) FileData {
+	return FileData{
+		MIMEType:   u.MIMEType,
+		Name:       fileName,
+		UploadFrom: u.Link(),
+	}
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// WebhookTopicAll matches every event. Webhook topics are composed of the type
+// of the resource and the action that triggered the webhook, for example
+// "Entry.publish". An asterisk matches all types or actions.
+const WebhookTopicAll = "*.*"
+
+// Webhook is a webhook definition. Contentful sends a HTTP request to the URL
+// of the webhook whenever an event matching one of its topics and filters
+// occurs in the space.
+type Webhook struct {
+	System `json:"sys,omitempty"`
+
+	Name    string           `json:"name"`
+	URL     string           `json:"url"`
+	Topics  []string         `json:"topics"`
+	Headers []*WebhookHeader `json:"headers,omitempty"`
+	Filters []WebhookFilter  `json:"filters,omitempty"`
+
+	HTTPBasicUsername string `json:"httpBasicUsername,omitempty"`
+	// HTTPBasicPassword is never returned by the API
+	HTTPBasicPassword string `json:"httpBasicPassword,omitempty"`
+
+	// Active is only sent when set, webhooks are active by default
+	Active *bool `json:"active,omitempty"`
+}
+
+// Validate will validate the webhook. An error is returned if the webhook is
+// not valid.
+func (w *Webhook) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("Webhook must specify a valid name")
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("Webhook must specify a valid URL")
+	}
+
+	if len(w.Topics) == 0 {
+		return fmt.Errorf("Webhook must specify at least one topic")
+	}
+
+	for _, header := range w.Headers {
+		if header == nil || header.Key == "" {
+			return fmt.Errorf("Webhook headers must specify a key")
+		}
+	}
+
+	return nil
+}
+
+// WebhookHeader is a custom header sent with every webhook request. The values
+// of secret headers are not returned by the API.
+type WebhookHeader struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Secret bool   `json:"secret,omitempty"`
+}
+
+// WebhookFilter restricts the events a webhook is triggered for. Filters are
+// built with the WebhookFilter functions, for example:
+//
+//	WebhookFilterEqual("sys.environment.sys.id", "master")
+type WebhookFilter map[string]interface{}
+
+// WebhookFilterEqual matches events where the property at path equals value
+func WebhookFilterEqual(path string, value string) WebhookFilter {
+	return WebhookFilter{"equals": []interface{}{webhookDoc(path), value}}
+}
+
+// WebhookFilterIn matches events where the property at path is one of values
+func WebhookFilterIn(path string, values ...string) WebhookFilter {
+	return WebhookFilter{"in": []interface{}{webhookDoc(path), values}}
+}
+
+// WebhookFilterRegexp matches events where the property at path matches the
+// regular expression
+func WebhookFilterRegexp(path string, pattern string) WebhookFilter {
+	return WebhookFilter{"regexp": []interface{}{webhookDoc(path), map[string]string{"pattern": pattern}}}
+}
+
+// WebhookFilterNot negates the filter
+func WebhookFilterNot(filter WebhookFilter) WebhookFilter {
+	return WebhookFilter{"not": filter}
+}
+
+func webhookDoc(path string) map[string]string {
+	return map[string]string{"doc": path}
+}
+
+// WebhookCall is a logged webhook request. The request and response details
+// are only returned when fetching a single call.
+type WebhookCall struct {
+	System `json:"sys"`
+
+	URL        string     `json:"url"`
+	EventType  string     `json:"eventType"`
+	StatusCode int        `json:"statusCode"`
+	Errors     []string   `json:"errors"`
+	RequestAt  *time.Time `json:"requestAt,omitempty"`
+	ResponseAt *time.Time `json:"responseAt,omitempty"`
+
+	Request  *WebhookCallRequest  `json:"request,omitempty"`
+	Response *WebhookCallResponse `json:"response,omitempty"`
+}
+
+// WebhookCallRequest is the request Contentful sent for a webhook call
+type WebhookCallRequest struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// WebhookCallResponse is the response Contentful received for a webhook call
+type WebhookCallResponse struct {
+	URL        string            `json:"url"`
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+}
+
+// WebhookHealth summarizes the most recent calls of a webhook
+type WebhookHealth struct {
+	System `json:"sys"`
+	Calls  struct {
+		Total   int `json:"total"`
+		Healthy int `json:"healthy"`
+	} `json:"calls"`
+}
@@ -0,0 +1,353 @@
+// Package recorder records HTTP interactions with Contentful to cassette files
+// and replays them, so tests can run offline and deterministically.
+//
+// A Recorder is an http.RoundTripper and a transport.Doer. Plug it into the
+// clients through their *http.Client argument:
+//
+//	rec, err := recorder.New("testdata/entries.json", recorder.ModeReplayOrRecord)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client := delivery.NewClient(token, "v1", rec.Client())
+//
+// Access tokens are scrubbed from the Authorization header and the
+// access_token query parameter before interactions are written, so cassettes
+// can be committed.
+package recorder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode controls whether requests are recorded or replayed
+type Mode int
+
+const (
+	// ModeReplay replays the interactions of an existing cassette. Requests
+	// that don't match a recorded interaction fail.
+	ModeReplay Mode = iota
+	// ModeRecord performs real requests and records them, replacing the
+	// cassette when the recorder is stopped.
+	ModeRecord
+	// ModeReplayOrRecord replays the cassette if it exists and records a new
+	// one otherwise.
+	ModeReplayOrRecord
+)
+
+// Scrubbed replaces access tokens in recorded requests
+const Scrubbed = "REDACTED"
+
+// Cassette holds the recorded interactions
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it received
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded request or response body. It is stored as text, or base64
+// encoded if it isn't valid UTF-8.
+type Body []byte
+
+// MarshalJSON encodes the body as a string, binary bodies are prefixed with
+// "base64:"
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+
+	return json.Marshal("base64:" + base64.StdEncoding.EncodeToString(b))
+}
+
+// UnmarshalJSON decodes bodies encoded by MarshalJSON
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if len(s) > len("base64:") && s[:len("base64:")] == "base64:" {
+		decoded, err := base64.StdEncoding.DecodeString(s[len("base64:"):])
+		if err != nil {
+			return err
+		}
+
+		*b = decoded
+		return nil
+	}
+
+	*b = Body(s)
+	return nil
+}
+
+// LoadCassette reads a cassette from a file
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := new(Cassette)
+	if err = json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("LoadCassette failed. %v: %v", path, err)
+	}
+
+	return cassette, nil
+}
+
+// Save writes the cassette to a file, creating its directory if necessary
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Recorder records or replays HTTP interactions
+type Recorder struct {
+	// Transport performs the real requests while recording. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	path     string
+	mode     Mode
+	mu       sync.Mutex
+	cassette *Cassette
+	// replayed marks the interactions that have been replayed
+	replayed []bool
+}
+
+// New creates a recorder for the cassette file. In ModeReplay the cassette
+// must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		mode:     mode,
+		cassette: &Cassette{Interactions: []*Interaction{}},
+	}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	cassette, err := LoadCassette(path)
+	switch {
+	case err == nil:
+		r.mode = ModeReplay
+		r.cassette = cassette
+		r.replayed = make([]bool, len(cassette.Interactions))
+	case os.IsNotExist(err) && mode == ModeReplayOrRecord:
+		r.mode = ModeRecord
+	default:
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Mode returns whether the recorder is recording or replaying. Recorders
+// created with ModeReplayOrRecord report the mode they chose.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client that uses the recorder as transport
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Do performs the request, so the recorder can be used as a transport.Doer
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	return r.RoundTrip(req)
+}
+
+// RoundTrip records the request and its response or replays the recorded
+// response of the first interaction with the same method, path and query that
+// has not been replayed yet.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+
+	return r.record(req)
+}
+
+// Stop writes the cassette if the recorder is recording
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	return r.cassette.Save(r.path)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	// The body is read by the recorder and the transport, the caller's
+	// request must not be modified
+	req = req.Clone(req.Context())
+
+	requestBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    scrubURL(req.URL).String(),
+			Header: scrubHeader(req.Header),
+			Body:   requestBody,
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       responseBody,
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := matchKey(req.Method, req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] {
+			continue
+		}
+
+		recorded, err := url.Parse(interaction.Request.URL)
+		if err != nil || matchKey(interaction.Request.Method, recorded) != key {
+			continue
+		}
+
+		r.replayed[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("Replay failed. No recorded interaction left for %v %v in %v", req.Method, scrubURL(req.URL), r.path)
+}
+
+// matchKey identifies requests by method, path and query. The query is
+// normalized by sorting the parameters and ignoring the access token.
+func matchKey(method string, u *url.URL) string {
+	q := u.Query()
+	q.Del("access_token")
+
+	return method + " " + u.Path + "?" + q.Encode()
+}
+
+// readBody reads the body and replaces it with an in-memory copy
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+
+	*body = ioutil.NopCloser(bytes.NewReader(data))
+
+	return data, nil
+}
+
+func scrubURL(u *url.URL) *url.URL {
+	scrubbed := *u
+
+	q := scrubbed.Query()
+	if q.Get("access_token") != "" {
+		q.Set("access_token", Scrubbed)
+		scrubbed.RawQuery = q.Encode()
+	}
+
+	return &scrubbed
+}
+
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	if scrubbed.Get("Authorization") != "" {
+		scrubbed.Set("Authorization", "Bearer "+Scrubbed)
+	}
+
+	return scrubbed
+}
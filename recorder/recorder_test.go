@@ -0,0 +1,109 @@
+package recorder
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+
+	"github.com/illyabusigin/contentful/contentfultest"
+	"github.com/illyabusigin/contentful/delivery"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+const accessToken = "secret-token"
+
+func newClient(baseURL string, rec *Recorder) *delivery.Client {
+	return delivery.New(accessToken,
+		delivery.WithBaseURL(baseURL),
+		delivery.WithHTTPClient(rec.Client()),
+		delivery.WithRateLimit(0, 0),
+		delivery.WithRetryPolicy(nil))
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := contentfultest.NewServer()
+	server.CreateSpace("space", "Recorded")
+
+	path := filepath.Join(t.TempDir(), "cassettes", "space.json")
+
+	rec, err := New(path, ModeReplayOrRecord)
+	assert.Nil(t, err)
+	assert.Equal(t, ModeRecord, rec.Mode())
+
+	client := newClient(server.DeliveryURL(), rec)
+	space, err := client.FetchSpace("space")
+	assert.Nil(t, err)
+	assert.Equal(t, "Recorded", space.Name)
+
+	_, err = client.FetchEntry("space", "missing")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	assert.Nil(t, rec.Stop())
+	server.Close()
+
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(string(data), accessToken))
+	assert.True(t, strings.Contains(string(data), "access_token="+Scrubbed))
+
+	cassette, err := LoadCassette(path)
+	assert.Nil(t, err)
+	assert.Len(t, cassette.Interactions, 2)
+
+	// The server is gone, responses come from the cassette
+	rec, err = New(path, ModeReplayOrRecord)
+	assert.Nil(t, err)
+	assert.Equal(t, ModeReplay, rec.Mode())
+
+	client = newClient(server.DeliveryURL(), rec)
+	space, err = client.FetchSpace("space")
+	assert.Nil(t, err)
+	assert.Equal(t, "Recorded", space.Name)
+
+	_, err = client.FetchEntry("space", "missing")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	// Every interaction is replayed once
+	_, err = client.FetchSpace("space")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "No recorded interaction left")
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	assert.NotNil(t, err)
+}
+
+func TestMatchKeyNormalizesQuery(t *testing.T) {
+	first := mustParse(t, "https://cdn.contentful.com/spaces/space/entries?limit=10&access_token=one&content_type=post")
+	second := mustParse(t, "https://cdn.contentful.com/spaces/space/entries?content_type=post&limit=10&access_token=two")
+	other := mustParse(t, "https://cdn.contentful.com/spaces/space/entries?content_type=page&limit=10")
+
+	assert.Equal(t, matchKey("GET", first), matchKey("GET", second))
+	assert.NotEqual(t, matchKey("GET", first), matchKey("GET", other))
+	assert.NotEqual(t, matchKey("GET", first), matchKey("DELETE", second))
+}
+
+func mustParse(t *testing.T, rawURL string) *url.URL {
+	u, err := url.Parse(rawURL)
+	assert.Nil(t, err)
+
+	return u
+}
+
+func TestBinaryBody(t *testing.T) {
+	body := Body{0xff, 0xfe, 0x00}
+
+	data, err := body.MarshalJSON()
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(data), `"base64:`))
+
+	decoded := Body{}
+	assert.Nil(t, decoded.UnmarshalJSON(data))
+	assert.Equal(t, body, decoded)
+}
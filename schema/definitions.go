@@ -0,0 +1,111 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	yaml "gopkg.in/yaml.v2"
+
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// ReadFile reads content type definitions from a YAML or JSON file, see Parse
+func ReadFile(path string) ([]*ContentType, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	contentTypes, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+
+	return contentTypes, nil
+}
+
+// Parse reads content type definitions from YAML. Content types are listed
+// under contentTypes and use the attributes of the Contentful API, except that
+// the identifier can be set with id instead of sys.id:
+//
+//	contentTypes:
+//	  - id: post
+//	    name: Post
+//	    displayField: title
+//	    fields:
+//	      - id: title
+//	        name: Title
+//	        type: Symbol
+//	        required: true
+//
+// JSON is valid YAML, so files written by the exporter can be read as well.
+func Parse(data []byte) ([]*ContentType, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("Parse failed. %v", err)
+	}
+
+	root, ok := stringKeys(document).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Parse failed. Expected a mapping with contentTypes!")
+	}
+
+	items, _ := root["contentTypes"].([]interface{})
+	for _, item := range items {
+		contentType, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if id, ok := contentType["id"]; ok {
+			sys, _ := contentType["sys"].(map[string]interface{})
+			if sys == nil {
+				sys = map[string]interface{}{}
+			}
+
+			sys["id"] = id
+			contentType["sys"] = sys
+			delete(contentType, "id")
+		}
+	}
+
+	// The models only have JSON tags
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("Parse failed. %v", err)
+	}
+
+	contentTypes := []*ContentType{}
+	if err = json.Unmarshal(encoded, &contentTypes); err != nil {
+		return nil, fmt.Errorf("Parse failed. %v", err)
+	}
+
+	for i, contentType := range contentTypes {
+		if contentType.ID == "" {
+			return nil, fmt.Errorf("Parse failed. Content type %v has no id!", i+1)
+		}
+	}
+
+	return contentTypes, nil
+}
+
+// stringKeys converts the maps decoded by yaml.v2 to maps with string keys,
+// which encoding/json requires
+func stringKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for key, item := range v {
+			m[fmt.Sprint(key)] = stringKeys(item)
+		}
+
+		return m
+	case []interface{}:
+		for i, item := range v {
+			v[i] = stringKeys(item)
+		}
+	}
+
+	return value
+}
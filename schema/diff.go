@@ -0,0 +1,364 @@
+// Package schema compares content models and keeps spaces in sync with
+// content types declared in Go or YAML. Compare lists the differences between
+// two sets of content types, for example local definitions and the content
+// types of a space, and flags changes that lose or invalidate entry values:
+//
+//	current, err := schema.Load(client, spaceID)
+//	diff := schema.Compare(current, definitions)
+//	fmt.Print(diff)
+//
+// Sync applies the differences to the space:
+//
+//	definitions, err := schema.ReadFile("content_types.yaml")
+//	diff, err := schema.Sync(client, spaceID, definitions, &schema.Options{Prune: true})
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// Action is the kind of a change
+type Action string
+
+// Change actions
+const (
+	Add    Action = "add"
+	Remove Action = "remove"
+	Update Action = "update"
+)
+
+// Change is a single difference between two sets of content types
+type Change struct {
+	Action        Action
+	ContentTypeID string
+	// FieldID is set for changes of a field
+	FieldID string
+	// Attribute is the changed attribute of updates, for example "type" or
+	// "required"
+	Attribute string
+	// From and To describe the old and new value. Added and removed fields
+	// are described by their type.
+	From string
+	To   string
+	// Destructive changes lose entry values or make existing entries invalid,
+	// the entries have to be transformed when the change is applied
+	Destructive bool
+}
+
+// String describes the change
+func (c *Change) String() string {
+	subject := "content type " + c.ContentTypeID
+	if c.FieldID != "" {
+		subject = "field " + c.FieldID
+	}
+
+	var s string
+	switch c.Action {
+	case Add:
+		s = fmt.Sprintf("add %v (%v)", subject, c.To)
+	case Remove:
+		s = fmt.Sprintf("remove %v (%v)", subject, c.From)
+	default:
+		s = fmt.Sprintf("change %v of %v: %v -> %v", c.Attribute, subject, c.From, c.To)
+	}
+
+	if c.Destructive {
+		s += " [destructive]"
+	}
+
+	return s
+}
+
+// Diff lists the changes turning one set of content types into another
+type Diff struct {
+	Changes []*Change
+}
+
+// Empty reports whether both sets of content types are the same
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Destructive returns the changes that lose or invalidate entry values
+func (d *Diff) Destructive() []*Change {
+	changes := []*Change{}
+	for _, change := range d.Changes {
+		if change.Destructive {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// ContentType returns the changes of a content type and its fields
+func (d *Diff) ContentType(id string) []*Change {
+	changes := []*Change{}
+	for _, change := range d.Changes {
+		if change.ContentTypeID == id {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// String prints the plan, grouped by content type
+func (d *Diff) String() string {
+	if d.Empty() {
+		return "No changes.\n"
+	}
+
+	b := &strings.Builder{}
+	added, updated, removed := 0, 0, 0
+	contentTypeID := ""
+
+	for _, change := range d.Changes {
+		if change.FieldID == "" && change.Action != Update {
+			if change.Action == Add {
+				added++
+				b.WriteString("+ ")
+			} else {
+				removed++
+				b.WriteString("- ")
+			}
+
+			fmt.Fprintf(b, "%v\n", change)
+			contentTypeID = change.ContentTypeID
+			continue
+		}
+
+		if change.ContentTypeID != contentTypeID {
+			updated++
+			fmt.Fprintf(b, "~ update content type %v\n", change.ContentTypeID)
+			contentTypeID = change.ContentTypeID
+		}
+
+		switch change.Action {
+		case Add:
+			b.WriteString("    + ")
+		case Remove:
+			b.WriteString("    - ")
+		default:
+			b.WriteString("    ~ ")
+		}
+
+		fmt.Fprintf(b, "%v\n", change)
+	}
+
+	fmt.Fprintf(b, "\nPlan: %v to add, %v to update, %v to remove, %v destructive changes.\n",
+		added, updated, removed, len(d.Destructive()))
+
+	return b.String()
+}
+
+// Load returns the content types of a space, including those that haven't
+// been activated
+func Load(client *management.Client, spaceID string) ([]*ContentType, error) {
+	contentTypes := []*ContentType{}
+
+	it := client.IterateContentTypes(spaceID, false)
+	for it.Next() {
+		contentTypes = append(contentTypes, it.ContentType())
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return contentTypes, nil
+}
+
+// Compare returns the changes turning the current content types into the
+// desired ones. Content types are matched by ID, changes are listed in the
+// order of the desired content types followed by removed content types.
+func Compare(current []*ContentType, desired []*ContentType) *Diff {
+	d := &Diff{Changes: []*Change{}}
+
+	index := map[string]*ContentType{}
+	for _, contentType := range current {
+		index[contentType.ID] = contentType
+	}
+
+	declared := map[string]bool{}
+	for _, contentType := range desired {
+		declared[contentType.ID] = true
+
+		if index[contentType.ID] == nil {
+			d.add(&Change{Action: Add, ContentTypeID: contentType.ID, To: contentType.Name})
+			for _, field := range contentType.Fields {
+				d.add(&Change{Action: Add, ContentTypeID: contentType.ID, FieldID: field.ID, To: describeType(&field)})
+			}
+			continue
+		}
+
+		d.compareContentType(index[contentType.ID], contentType)
+	}
+
+	for _, contentType := range current {
+		if !declared[contentType.ID] {
+			d.add(&Change{Action: Remove, ContentTypeID: contentType.ID, From: contentType.Name, Destructive: true})
+		}
+	}
+
+	return d
+}
+
+func (d *Diff) add(change *Change) {
+	d.Changes = append(d.Changes, change)
+}
+
+// update adds an update of an attribute if the values differ
+func (d *Diff) update(contentTypeID string, fieldID string, attribute string, from string, to string, destructive bool) {
+	if from == to {
+		return
+	}
+
+	d.add(&Change{
+		Action:        Update,
+		ContentTypeID: contentTypeID,
+		FieldID:       fieldID,
+		Attribute:     attribute,
+		From:          from,
+		To:            to,
+		Destructive:   destructive,
+	})
+}
+
+func (d *Diff) compareContentType(current *ContentType, desired *ContentType) {
+	id := desired.ID
+	d.update(id, "", "name", quote(current.Name), quote(desired.Name), false)
+	d.update(id, "", "description", quote(current.Description), quote(desired.Description), false)
+	d.update(id, "", "displayField", quote(current.DisplayField), quote(desired.DisplayField), false)
+
+	fields := map[string]*Field{}
+	for i := range current.Fields {
+		fields[current.Fields[i].ID] = &current.Fields[i]
+	}
+
+	declared := map[string]bool{}
+	currentOrder, desiredOrder := []string{}, []string{}
+	for i := range desired.Fields {
+		field := &desired.Fields[i]
+		declared[field.ID] = true
+
+		if fields[field.ID] == nil {
+			// Existing entries have no value for the field, they can no longer be
+			// published if it is required
+			d.add(&Change{Action: Add, ContentTypeID: id, FieldID: field.ID, To: describeType(field), Destructive: field.Required})
+			continue
+		}
+
+		desiredOrder = append(desiredOrder, field.ID)
+		d.compareField(id, fields[field.ID], field)
+	}
+
+	for _, field := range current.Fields {
+		if !declared[field.ID] {
+			d.add(&Change{Action: Remove, ContentTypeID: id, FieldID: field.ID, From: describeType(&field), Destructive: true})
+		} else {
+			currentOrder = append(currentOrder, field.ID)
+		}
+	}
+
+	d.update(id, "", "field order", strings.Join(currentOrder, ", "), strings.Join(desiredOrder, ", "), false)
+}
+
+func (d *Diff) compareField(contentTypeID string, current *Field, desired *Field) {
+	update := func(attribute string, from string, to string, destructive bool) {
+		d.update(contentTypeID, desired.ID, attribute, from, to, destructive)
+	}
+
+	// Values of another type have to be converted
+	update("type", string(current.Type), string(desired.Type), true)
+	update("linkType", quote(current.LinkType), quote(desired.LinkType), true)
+
+	if current.Items != nil || desired.Items != nil {
+		currentItems, desiredItems := current.Items, desired.Items
+		if currentItems == nil {
+			currentItems = &Field{}
+		}
+		if desiredItems == nil {
+			desiredItems = &Field{}
+		}
+
+		update("items", describeType(currentItems), describeType(desiredItems), true)
+		from, to := describeValidations(currentItems.Validations), describeValidations(desiredItems.Validations)
+		update("items validations", from, to, stricter(currentItems.Validations, desiredItems.Validations))
+	}
+
+	update("name", quote(current.Name), quote(desired.Name), false)
+
+	// Entries without a value can no longer be published
+	update("required", fmt.Sprint(current.Required), fmt.Sprint(desired.Required), desired.Required)
+	// Values of other locales than the default locale are lost
+	update("localized", fmt.Sprint(current.Localized), fmt.Sprint(desired.Localized), !desired.Localized)
+
+	from, to := describeValidations(current.Validations), describeValidations(desired.Validations)
+	update("validations", from, to, stricter(current.Validations, desired.Validations))
+
+	update("omitted", fmt.Sprint(current.Omitted), fmt.Sprint(desired.Omitted), false)
+	update("disabled", fmt.Sprint(current.Disabled), fmt.Sprint(desired.Disabled), false)
+}
+
+// stricter reports whether desired has validations current doesn't have,
+// existing entries may fail them
+func stricter(current []FieldValidation, desired []FieldValidation) bool {
+	existing := map[string]bool{}
+	for _, validation := range current {
+		existing[describe(validation)] = true
+	}
+
+	for _, validation := range desired {
+		if !existing[describe(validation)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// describeType describes the type of a field, for example "Array of Link to
+// Entry"
+func describeType(field *Field) string {
+	s := string(field.Type)
+	if s == "" {
+		s = "none"
+	}
+
+	if field.LinkType != "" {
+		s += " to " + field.LinkType
+	}
+
+	if field.Items != nil {
+		s += " of " + describeType(field.Items)
+	}
+
+	return s
+}
+
+func describeValidations(validations []FieldValidation) string {
+	if len(validations) == 0 {
+		return "none"
+	}
+
+	return describe(validations)
+}
+
+func describe(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
+
+func quote(s string) string {
+	return fmt.Sprintf("%q", s)
+}
@@ -0,0 +1,135 @@
+package schema
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+
+	. "github.com/illyabusigin/contentful/models"
+)
+
+func post() *ContentType {
+	return &ContentType{
+		System:       System{ID: "post"},
+		Name:         "Post",
+		DisplayField: "title",
+		Fields: []Field{
+			{ID: "title", Name: "Title", Type: ShortText, Localized: true},
+			{ID: "body", Name: "Body", Type: LongText},
+			{ID: "byline", Name: "Byline", Type: ShortText},
+			{ID: "tags", Name: "Tags", Type: Array, Items: &Field{Type: ShortText}},
+		},
+	}
+}
+
+func TestCompareUnchanged(t *testing.T) {
+	diff := Compare([]*ContentType{post()}, []*ContentType{post()})
+	assert.True(t, diff.Empty())
+	assert.Equal(t, "No changes.\n", diff.String())
+}
+
+func TestCompare(t *testing.T) {
+	legacy := &ContentType{System: System{ID: "legacy"}, Name: "Legacy"}
+	max := float64(1000)
+
+	desired := post()
+	desired.DisplayField = "headline"
+	desired.Fields = []Field{
+		{ID: "title", Name: "Headline", Type: LongText, Required: true},
+		{ID: "tags", Name: "Tags", Type: Array, Items: &Field{Type: LinkType, LinkType: "Entry"}},
+		{ID: "body", Name: "Body", Type: LongText, Localized: true, Omitted: true,
+			Validations: []FieldValidation{{Size: &SizeFieldValidation{Max: &max}}}},
+		{ID: "author", Name: "Author", Type: LinkType, LinkType: "Entry"},
+	}
+
+	author := &ContentType{
+		System: System{ID: "author"},
+		Name:   "Author",
+		Fields: []Field{{ID: "name", Name: "Name", Type: ShortText}},
+	}
+
+	diff := Compare([]*ContentType{post(), legacy}, []*ContentType{desired, author})
+
+	changes := []string{}
+	for _, change := range diff.Changes {
+		changes = append(changes, change.String())
+	}
+
+	assert.Equal(t, []string{
+		`change displayField of content type post: "title" -> "headline"`,
+		`change type of field title: Symbol -> Text [destructive]`,
+		`change name of field title: "Title" -> "Headline"`,
+		`change required of field title: false -> true [destructive]`,
+		`change localized of field title: true -> false [destructive]`,
+		`change items of field tags: Symbol -> Link to Entry [destructive]`,
+		`change localized of field body: false -> true`,
+		`change validations of field body: none -> [{"size":{"max":1000}}] [destructive]`,
+		`change omitted of field body: false -> true`,
+		`add field author (Link to Entry)`,
+		`remove field byline (Symbol) [destructive]`,
+		`change field order of content type post: title, body, tags -> title, tags, body`,
+		`add content type author (Author)`,
+		`add field name (Symbol)`,
+		`remove content type legacy (Legacy) [destructive]`,
+	}, changes)
+
+	assert.Len(t, diff.Destructive(), 7)
+	assert.Len(t, diff.ContentType("author"), 2)
+
+	assert.Contains(t, diff.String(), `~ update content type post
+    ~ change displayField of content type post: "title" -> "headline"
+`)
+	assert.Contains(t, diff.String(), `    - remove field byline (Symbol) [destructive]
+`)
+	assert.Contains(t, diff.String(), `+ add content type author (Author)
+    + add field name (Symbol)
+- remove content type legacy (Legacy) [destructive]
+
+Plan: 1 to add, 1 to update, 1 to remove, 7 destructive changes.
+`)
+}
+
+func TestCompareAddedFields(t *testing.T) {
+	desired := post()
+	desired.Fields = append(desired.Fields,
+		Field{ID: "summary", Name: "Summary", Type: ShortText, Required: true},
+		Field{ID: "slug", Name: "Slug", Type: ShortText})
+
+	// Existing entries have no summary and can no longer be published
+	diff := Compare([]*ContentType{post()}, []*ContentType{desired})
+	assert.Len(t, diff.Changes, 2)
+	assert.Equal(t, "add field summary (Symbol) [destructive]", diff.Changes[0].String())
+	assert.Equal(t, "add field slug (Symbol)", diff.Changes[1].String())
+	assert.Len(t, diff.Destructive(), 1)
+
+	// New content types have no entries
+	desired.ID = "page"
+	diff = Compare(nil, []*ContentType{desired})
+	assert.Empty(t, diff.Destructive())
+}
+
+func TestCompareValidations(t *testing.T) {
+	max := float64(100)
+	size := FieldValidation{Size: &SizeFieldValidation{Max: &max}}
+	in := FieldValidation{In: []interface{}{"a", "b"}}
+
+	current := post()
+	current.Fields[0].Validations = []FieldValidation{size, in}
+
+	// Dropping a validation doesn't invalidate entries
+	desired := post()
+	desired.Fields[0].Validations = []FieldValidation{size}
+
+	diff := Compare([]*ContentType{current}, []*ContentType{desired})
+	assert.Len(t, diff.Changes, 1)
+	assert.False(t, diff.Changes[0].Destructive)
+
+	// Items validations are compared too
+	desired = post()
+	desired.Fields[3].Items.Validations = []FieldValidation{in}
+
+	diff = Compare([]*ContentType{post()}, []*ContentType{desired})
+	assert.Len(t, diff.Changes, 1)
+	assert.Equal(t, "items validations", diff.Changes[0].Attribute)
+	assert.True(t, diff.Changes[0].Destructive)
+}
@@ -0,0 +1,238 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// Options configures Sync
+type Options struct {
+	// Prune deletes the fields and content types of the space that aren't
+	// declared. Without it undeclared fields are kept after the declared
+	// fields and undeclared content types are left untouched. Declare the
+	// content type recording migrations when using package migration.
+	Prune bool
+	// DryRun returns the changes without applying them
+	DryRun bool
+}
+
+// Sync reconciles the content types of a space with the declared content
+// types. Missing content types are created, changed ones are updated, and both
+// are activated. Removed fields are omitted and activated before they are
+// deleted, content types are deactivated before they are deleted, which fails
+// if they still have entries.
+//
+// Sync returns the changes it applied. Destructive changes are applied as well,
+// use Compare and the migration package to transform entries first.
+func Sync(client *management.Client, spaceID string, declared []*ContentType, options *Options) (*Diff, error) {
+	if spaceID == "" {
+		return nil, fmt.Errorf("Sync failed. Space identifier is not valid!")
+	}
+
+	if options == nil {
+		options = &Options{}
+	}
+
+	ids := map[string]bool{}
+	for _, contentType := range declared {
+		if contentType.ID == "" {
+			return nil, fmt.Errorf("Sync failed. Content type %q has no identifier!", contentType.Name)
+		}
+
+		if ids[contentType.ID] {
+			return nil, fmt.Errorf("Sync failed. Content type %v is declared twice!", contentType.ID)
+		}
+
+		ids[contentType.ID] = true
+	}
+
+	current, err := Load(client, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := map[string]*ContentType{}
+	for _, contentType := range current {
+		index[contentType.ID] = contentType
+	}
+
+	// Without pruning the undeclared fields and content types are part of the
+	// desired state
+	desired := []*ContentType{}
+	for _, contentType := range declared {
+		if existing := index[contentType.ID]; existing != nil && !options.Prune {
+			contentType = keepFields(existing, contentType)
+		}
+
+		desired = append(desired, contentType)
+	}
+
+	if !options.Prune {
+		current = filter(current, ids)
+	}
+
+	diff := Compare(current, desired)
+	if options.DryRun {
+		return diff, nil
+	}
+
+	s := &syncer{client: client, spaceID: spaceID}
+	for _, contentType := range desired {
+		existing := index[contentType.ID]
+		if existing == nil {
+			err = s.create(contentType)
+		} else if len(diff.ContentType(contentType.ID)) > 0 || !existing.IsPublished() || existing.IsChanged() {
+			err = s.update(existing, contentType)
+		}
+
+		if err != nil {
+			return diff, fmt.Errorf("Sync failed. Content type %v: %w", contentType.ID, err)
+		}
+	}
+
+	if !options.Prune {
+		return diff, nil
+	}
+
+	for _, contentType := range current {
+		if ids[contentType.ID] {
+			continue
+		}
+
+		if err = s.delete(contentType); err != nil {
+			return diff, fmt.Errorf("Sync failed. Deleting content type %v: %w", contentType.ID, err)
+		}
+	}
+
+	return diff, nil
+}
+
+// syncer applies the changes to a space
+type syncer struct {
+	client  *management.Client
+	spaceID string
+}
+
+func (s *syncer) space() *Link {
+	return &Link{LinkData: &LinkData{Type: LinkType, LinkType: "Space", ID: s.spaceID}}
+}
+
+func (s *syncer) create(declared *ContentType) error {
+	contentType := *declared
+	contentType.System = System{ID: declared.ID, Space: s.space()}
+
+	created, err := s.client.CreateContentType(&contentType)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.activate(created)
+
+	return err
+}
+
+// update updates the content type with the declared definition. Fields that
+// are removed have to be omitted first.
+func (s *syncer) update(existing *ContentType, declared *ContentType) error {
+	declaredFields := map[string]bool{}
+	for _, field := range declared.Fields {
+		declaredFields[field.ID] = true
+	}
+
+	omit := *existing
+	omit.Fields = append([]Field{}, existing.Fields...)
+	omitted := false
+	for i := range omit.Fields {
+		if !declaredFields[omit.Fields[i].ID] && !omit.Fields[i].Omitted {
+			omit.Fields[i].Omitted = true
+			omitted = true
+		}
+	}
+
+	version := existing.Version
+	if omitted {
+		updated, err := s.save(&omit, version)
+		if err != nil {
+			return err
+		}
+
+		activated, err := s.activate(updated)
+		if err != nil {
+			return err
+		}
+
+		version = activated.Version
+	}
+
+	contentType := *declared
+	updated, err := s.save(&contentType, version)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.activate(updated)
+
+	return err
+}
+
+// save updates the content type, version is the current version in the space
+func (s *syncer) save(contentType *ContentType, version int) (*ContentType, error) {
+	contentType.System = System{ID: contentType.ID, Version: version, Space: s.space()}
+
+	return s.client.UpdateContentType(contentType)
+}
+
+func (s *syncer) activate(contentType *ContentType) (*ContentType, error) {
+	if contentType.Space == nil {
+		contentType.Space = s.space()
+	}
+
+	return s.client.ActivateContentType(contentType)
+}
+
+func (s *syncer) delete(contentType *ContentType) error {
+	if contentType.IsPublished() {
+		if contentType.Space == nil {
+			contentType.Space = s.space()
+		}
+
+		if _, err := s.client.DeactivateContentType(contentType); err != nil {
+			return err
+		}
+	}
+
+	return s.client.DeleteContentType(s.spaceID, contentType.ID)
+}
+
+// keepFields returns a copy of the declared content type followed by the
+// fields of the existing content type that aren't declared
+func keepFields(existing *ContentType, declared *ContentType) *ContentType {
+	contentType := *declared
+	contentType.Fields = append([]Field{}, declared.Fields...)
+
+	ids := map[string]bool{}
+	for _, field := range declared.Fields {
+		ids[field.ID] = true
+	}
+
+	for _, field := range existing.Fields {
+		if !ids[field.ID] {
+			contentType.Fields = append(contentType.Fields, field)
+		}
+	}
+
+	return &contentType
+}
+
+func filter(contentTypes []*ContentType, ids map[string]bool) []*ContentType {
+	filtered := []*ContentType{}
+	for _, contentType := range contentTypes {
+		if ids[contentType.ID] {
+			filtered = append(filtered, contentType)
+		}
+	}
+
+	return filtered
+}
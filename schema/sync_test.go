@@ -0,0 +1,190 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+
+	"github.com/illyabusigin/contentful/contentfultest"
+	"github.com/illyabusigin/contentful/management"
+	. "github.com/illyabusigin/contentful/models"
+)
+
+const definitions = `
+contentTypes:
+  - id: post
+    name: Post
+    displayField: title
+    fields:
+      - id: title
+        name: Headline
+        type: Symbol
+        required: true
+      - id: author
+        name: Author
+        type: Link
+        linkType: Entry
+        validations:
+          - linkContentType: [author]
+  - id: author
+    name: Author
+    displayField: name
+    fields:
+      - id: name
+        name: Name
+        type: Symbol
+`
+
+// updates returns the content types sent by the recorded updates
+func updates(t *testing.T, writes *contentfultest.Writes) []*ContentType {
+	contentTypes := []*ContentType{}
+	for _, write := range writes.All() {
+		if write.Method != http.MethodPut || strings.HasSuffix(write.Path, "/published") {
+			continue
+		}
+
+		contentType := &ContentType{}
+		assert.Nil(t, json.Unmarshal(write.Body, contentType))
+		contentTypes = append(contentTypes, contentType)
+	}
+
+	return contentTypes
+}
+
+func setup(t *testing.T) (*contentfultest.Server, *management.Client, *contentfultest.Writes) {
+	server := contentfultest.NewServer()
+	server.CreateSpace("space", "Space")
+
+	w := &contentfultest.Writes{}
+	client := server.ManagementClient(management.WithMiddleware(w.Middleware))
+
+	// The space has a post with a legacy field and an unused content type
+	existing := post()
+	existing.Fields = existing.Fields[:3]
+	legacy := &ContentType{System: System{ID: "legacy"}, Name: "Legacy"}
+
+	_, err := Sync(client, "space", []*ContentType{existing, legacy}, nil)
+	assert.Nil(t, err)
+
+	w.Reset()
+
+	return server, client, w
+}
+
+func TestSync(t *testing.T) {
+	server, client, w := setup(t)
+	defer server.Close()
+
+	declared, err := Parse([]byte(definitions))
+	assert.Nil(t, err)
+
+	diff, err := Sync(client, "space", declared, nil)
+	assert.Nil(t, err)
+	assert.Len(t, diff.ContentType("author"), 2)
+	assert.Empty(t, diff.ContentType("legacy"))
+	assert.Equal(t, []string{
+		"PUT content_types/post",
+		"PUT content_types/post/published",
+		"PUT content_types/author",
+		"PUT content_types/author/published",
+	}, w.Strings())
+
+	post, err := client.FetchContentType("space", "post")
+	assert.Nil(t, err)
+	assert.True(t, post.IsPublished())
+	assert.False(t, post.IsChanged())
+	assert.Equal(t, "Headline", post.Fields[0].Name)
+	assert.Equal(t, []string{"author"}, post.Fields[1].Validations[0].LinkContentTypes)
+
+	// Undeclared fields are kept
+	ids := []string{}
+	for _, field := range post.Fields {
+		ids = append(ids, field.ID)
+	}
+	assert.Equal(t, []string{"title", "author", "body", "byline"}, ids)
+
+	author, err := client.FetchContentType("space", "author")
+	assert.Nil(t, err)
+	assert.True(t, author.IsPublished())
+
+	// Syncing again changes nothing
+	w.Reset()
+	diff, err = Sync(client, "space", declared, nil)
+	assert.Nil(t, err)
+	assert.True(t, diff.Empty(), diff.String())
+	assert.Empty(t, w.All())
+}
+
+func TestSyncPrune(t *testing.T) {
+	server, client, w := setup(t)
+	defer server.Close()
+
+	declared, err := Parse([]byte(definitions))
+	assert.Nil(t, err)
+
+	diff, err := Sync(client, "space", declared, &Options{Prune: true})
+	assert.Nil(t, err)
+	assert.Len(t, diff.Destructive(), 5, diff.String())
+
+	// The removed fields are omitted before they are deleted
+	requests := w.Strings()
+	assert.Equal(t, "PUT content_types/post", requests[0])
+	updated := updates(t, w)
+	assert.Len(t, updated[0].Fields, 3)
+	assert.True(t, updated[0].Fields[1].Omitted)
+	assert.True(t, updated[0].Fields[2].Omitted)
+	assert.Len(t, updated[1].Fields, 2)
+
+	assert.Equal(t, []string{
+		"DELETE content_types/legacy/published",
+		"DELETE content_types/legacy",
+	}, requests[len(requests)-2:])
+
+	_, err = client.FetchContentType("space", "legacy")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	post, err := client.FetchContentType("space", "post")
+	assert.Nil(t, err)
+	assert.Len(t, post.Fields, 2)
+	assert.False(t, post.IsChanged())
+}
+
+func TestSyncDryRun(t *testing.T) {
+	server, client, w := setup(t)
+	defer server.Close()
+
+	declared, err := Parse([]byte(definitions))
+	assert.Nil(t, err)
+
+	diff, err := Sync(client, "space", declared, &Options{Prune: true, DryRun: true})
+	assert.Nil(t, err)
+	assert.False(t, diff.Empty())
+	assert.Empty(t, w.All())
+
+	_, err = Sync(client, "space", append(declared, declared[0]), nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "declared twice")
+}
+
+func TestParse(t *testing.T) {
+	contentTypes, err := Parse([]byte(definitions))
+	assert.Nil(t, err)
+	assert.Len(t, contentTypes, 2)
+	assert.Equal(t, "post", contentTypes[0].ID)
+	assert.Equal(t, Field{ID: "title", Name: "Headline", Type: ShortText, Required: true}, contentTypes[0].Fields[0])
+
+	// JSON with sys.id, as written by the exporter
+	contentTypes, err = Parse([]byte(`{"contentTypes": [{"sys": {"id": "post", "version": 3}, "name": "Post"}]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "post", contentTypes[0].ID)
+
+	_, err = Parse([]byte("contentTypes:\n  - name: Post\n"))
+	assert.NotNil(t, err)
+
+	_, err = Parse([]byte("- post"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,134 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Logger receives debug information about the requests performed by the
+// clients. It is implemented by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Level is the severity of a log record. The values match those of
+// log/slog.
+type Level int
+
+// Log levels
+const (
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// Field is a key-value pair attached to a log record
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// StructuredLogger receives structured records about the requests performed
+// by the clients. NewSlogLogger adapts a *slog.Logger and PrintfLogger a
+// Logger.
+type StructuredLogger interface {
+	Log(ctx context.Context, level Level, msg string, fields ...Field)
+}
+
+// PrintfLogger adapts a Logger such as *log.Logger. Records are printed as the
+// message followed by the fields:
+//
+//	DEBUG contentful request method=GET path=/spaces/abc/entries status=200 duration=84ms
+func PrintfLogger(logger Logger) StructuredLogger {
+	return &printfLogger{logger: logger}
+}
+
+type printfLogger struct {
+	logger Logger
+}
+
+func (l *printfLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	parts := []string{level.String(), msg}
+	for _, field := range fields {
+		parts = append(parts, fmt.Sprintf("%v=%v", field.Key, field.Value))
+	}
+
+	l.logger.Printf("%v", strings.Join(parts, " "))
+}
+
+// RequestMessage is the message of the records logged for every request
+const RequestMessage = "contentful request"
+
+// rateLimitHeaders maps the rate limit response headers to their log fields
+var rateLimitHeaders = []struct {
+	header string
+	key    string
+}{
+	{"X-Contentful-RateLimit-Hour-Limit", "ratelimit_hour_limit"},
+	{"X-Contentful-RateLimit-Hour-Remaining", "ratelimit_hour_remaining"},
+	{"X-Contentful-RateLimit-Second-Limit", "ratelimit_second_limit"},
+	{"X-Contentful-RateLimit-Second-Remaining", "ratelimit_second_remaining"},
+	{"X-Contentful-RateLimit-Reset", "ratelimit_reset"},
+}
+
+// RequestFields returns the fields logged for a request: its method, path,
+// status, duration, request ID and the rate limit headers of the response, as
+// well as the error if the request failed.
+func RequestFields(req *http.Request, resp *http.Response, duration time.Duration, err error) []Field {
+	fields := []Field{
+		{"method", req.Method},
+		{"path", req.URL.Path},
+	}
+
+	if resp != nil {
+		fields = append(fields, Field{"status", resp.StatusCode})
+	}
+
+	fields = append(fields, Field{"duration", duration})
+
+	if resp != nil {
+		if requestID := resp.Header.Get("X-Contentful-Request-Id"); requestID != "" {
+			fields = append(fields, Field{"request_id", requestID})
+		}
+
+		for _, h := range rateLimitHeaders {
+			if value := resp.Header.Get(h.header); value != "" {
+				fields = append(fields, Field{h.key, value})
+			}
+		}
+	}
+
+	if err != nil {
+		fields = append(fields, Field{"error", err})
+	}
+
+	return fields
+}
+
+// LogRequest logs the request at debug level, if logger is set
+func LogRequest(ctx context.Context, logger StructuredLogger, req *http.Request, resp *http.Response, duration time.Duration, err error) {
+	if logger == nil {
+		return
+	}
+
+	logger.Log(ctx, LevelDebug, RequestMessage, RequestFields(req, resp, duration, err)...)
+}
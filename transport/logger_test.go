@@ -0,0 +1,66 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+type bufferLogger struct {
+	lines []string
+}
+
+func (l *bufferLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestRequestFields(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://api.contentful.com/spaces/abc/entries?limit=10", nil)
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Contentful-Request-Id":               []string{"req-1"},
+			"X-Contentful-Ratelimit-Hour-Remaining": []string{"35999"},
+			"X-Contentful-Ratelimit-Second-Limit":   []string{"10"},
+		},
+	}
+
+	fields := RequestFields(req, resp, 84*time.Millisecond, nil)
+	assert.Equal(t, []Field{
+		{"method", "GET"},
+		{"path", "/spaces/abc/entries"},
+		{"status", 200},
+		{"duration", 84 * time.Millisecond},
+		{"request_id", "req-1"},
+		{"ratelimit_hour_remaining", "35999"},
+		{"ratelimit_second_limit", "10"},
+	}, fields)
+
+	// Failed requests have no response
+	err := errors.New("connection refused")
+	fields = RequestFields(req, nil, time.Second, err)
+	assert.Equal(t, []Field{
+		{"method", "GET"},
+		{"path", "/spaces/abc/entries"},
+		{"duration", time.Second},
+		{"error", err},
+	}, fields)
+}
+
+func TestPrintfLogger(t *testing.T) {
+	buffer := &bufferLogger{}
+	logger := PrintfLogger(buffer)
+
+	req, _ := http.NewRequest("DELETE", "https://api.contentful.com/spaces/abc", nil)
+	LogRequest(context.Background(), logger, req, &http.Response{StatusCode: 204, Header: http.Header{}}, 2*time.Second, nil)
+	assert.Equal(t, []string{"DEBUG contentful request method=DELETE path=/spaces/abc status=204 duration=2s"}, buffer.lines)
+
+	// A nil logger is ignored
+	LogRequest(context.Background(), nil, req, nil, 0, nil)
+	assert.Len(t, buffer.lines, 1)
+}
@@ -0,0 +1,120 @@
+package transport
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps a Doer to add behavior to every request, such as setting
+// headers or recording metrics.
+type Middleware func(Doer) Doer
+
+// DoerFunc adapts a function to the Doer interface
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain wraps the doer with the middlewares. The first middleware is the
+// outermost one and sees the request first:
+//
+//	doer := transport.Chain(http.DefaultClient,
+//		transport.RequestID(nil),
+//		transport.Logging(logger),
+//		transport.Retries(transport.DefaultRetryPolicy()))
+func Chain(doer Doer, middlewares ...Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+
+	return doer
+}
+
+// Headers sets the headers on every request. Headers already set on the
+// request are kept.
+func Headers(headers http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for key, values := range headers {
+				if req.Header.Get(key) == "" {
+					req.Header[http.CanonicalHeaderKey(key)] = append([]string{}, values...)
+				}
+			}
+
+			return next.Do(req)
+		})
+	}
+}
+
+// RequestIDHeader is the header set by the RequestID middleware
+const RequestIDHeader = "X-Request-Id"
+
+// RequestID sets the X-Request-Id header on requests that don't have one, so
+// requests can be correlated across services. IDs are random hex strings
+// unless generate is set.
+func RequestID(generate func() string) Middleware {
+	if generate == nil {
+		generate = randomID
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(RequestIDHeader) == "" {
+				req = req.Clone(req.Context())
+				req.Header.Set(RequestIDHeader, generate())
+			}
+
+			return next.Do(req)
+		})
+	}
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// Logging logs every request at debug level, see LogRequest
+func Logging(logger StructuredLogger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			LogRequest(req.Context(), logger, req, resp, time.Since(start), err)
+
+			return resp, err
+		})
+	}
+}
+
+// Observer receives the outcome of every request. The response is nil if the
+// request failed.
+type Observer func(req *http.Request, resp *http.Response, duration time.Duration, err error)
+
+// Metrics reports every request to the observer, for example to update
+// Prometheus counters and histograms.
+func Metrics(observe Observer) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			observe(req, resp, time.Since(start), err)
+
+			return resp, err
+		})
+	}
+}
+
+// Retries retries failed requests according to the policy, see Retry
+func Retries(policy *RetryPolicy) Middleware {
+	return func(next Doer) Doer {
+		return Retry(next, policy)
+	}
+}
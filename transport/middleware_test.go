@@ -0,0 +1,87 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestChainOrder(t *testing.T) {
+	order := []string{}
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	doer := &sequenceDoer{statuses: []int{200}}
+	req, _ := http.NewRequest("GET", "https://cdn.contentful.com/spaces/abc", nil)
+
+	_, err := Chain(doer, trace("outer"), trace("inner")).Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+}
+
+func TestHeadersAndRequestID(t *testing.T) {
+	var received *http.Request
+	doer := DoerFunc(func(req *http.Request) (*http.Response, error) {
+		received = req
+		return &http.Response{StatusCode: 200, Header: http.Header{}}, nil
+	})
+
+	chain := Chain(doer,
+		Headers(http.Header{"X-Team": []string{"content"}, "User-Agent": []string{"default"}}),
+		RequestID(func() string { return "req-1" }))
+
+	req, _ := http.NewRequest("GET", "https://cdn.contentful.com/spaces/abc", nil)
+	req.Header.Set("User-Agent", "importer/1.0")
+
+	_, err := chain.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, "content", received.Header.Get("X-Team"))
+	assert.Equal(t, "importer/1.0", received.Header.Get("User-Agent"))
+	assert.Equal(t, "req-1", received.Header.Get(RequestIDHeader))
+	assert.Equal(t, "", req.Header.Get(RequestIDHeader), "Original request should not be modified")
+
+	// Existing request IDs are kept and generated IDs are unique
+	req.Header.Set(RequestIDHeader, "upstream")
+	Chain(doer, RequestID(nil)).Do(req)
+	assert.Equal(t, "upstream", received.Header.Get(RequestIDHeader))
+
+	req.Header.Del(RequestIDHeader)
+	Chain(doer, RequestID(nil)).Do(req)
+	first := received.Header.Get(RequestIDHeader)
+	Chain(doer, RequestID(nil)).Do(req)
+	assert.Len(t, first, 32)
+	assert.NotEqual(t, first, received.Header.Get(RequestIDHeader))
+}
+
+func TestLoggingAndMetrics(t *testing.T) {
+	logger := &bufferLogger{}
+	statuses := []int{}
+
+	doer := &sequenceDoer{statuses: []int{503, 200}}
+	chain := Chain(doer,
+		Metrics(func(req *http.Request, resp *http.Response, duration time.Duration, err error) {
+			statuses = append(statuses, resp.StatusCode)
+		}),
+		Retries(testPolicy()),
+		Logging(PrintfLogger(logger)))
+
+	req, _ := http.NewRequest("GET", "https://cdn.contentful.com/spaces/abc", nil)
+	resp, err := chain.Do(req.WithContext(context.Background()))
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	// Metrics wrap the retries, every attempt is logged
+	assert.Equal(t, []int{200}, statuses)
+	assert.Len(t, logger.lines, 2)
+	assert.Contains(t, logger.lines[0], "status=503")
+	assert.Contains(t, logger.lines[1], "status=200")
+}
@@ -0,0 +1,206 @@
+package transport
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy describes how requests that failed because of rate limiting or
+// server errors are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one
+	MaxAttempts int
+	// MaxElapsed caps the total time spent on a request including all waits.
+	// Zero means no cap.
+	MaxElapsed time.Duration
+
+	// BaseDelay is the delay before the first retry. It doubles for every
+	// subsequent attempt up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// StatusCodes are the response status codes that are retried
+	StatusCodes []int
+
+	// RetryNonIdempotent enables retries for POST and PATCH requests. Only
+	// enable it if creating duplicate resources is acceptable.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy used by the clients unless configured
+// otherwise.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 5,
+		MaxElapsed:  2 * time.Minute,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		StatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// Retry wraps the doer so requests are retried according to the policy. A nil
+// policy disables retries.
+func Retry(doer Doer, policy *RetryPolicy) Doer {
+	if policy == nil || policy.MaxAttempts <= 1 {
+		return doer
+	}
+
+	return &retryDoer{
+		doer:   doer,
+		policy: policy,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+type retryDoer struct {
+	doer   Doer
+	policy *RetryPolicy
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	if !d.policy.idempotent(req) {
+		return d.doer.Do(req)
+	}
+
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := d.doer.Do(req)
+		if attempt >= d.policy.MaxAttempts || !d.policy.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := d.delay(attempt, resp)
+		if d.policy.MaxElapsed > 0 && time.Since(start)+delay > d.policy.MaxElapsed {
+			return resp, err
+		}
+
+		// The request body has already been consumed and must be recreated
+		// before the request can be sent again.
+		next := req
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+
+			next = req.Clone(req.Context())
+			next.Body = body
+		}
+
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		req = next
+	}
+}
+
+// delay returns how long to wait before the next attempt. The rate limit
+// headers sent by Contentful take precedence over the exponential backoff.
+func (d *retryDoer) delay(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := RetryAfter(resp); ok {
+		return wait + d.jitter(d.policy.BaseDelay)
+	}
+
+	backoff := d.policy.BaseDelay << uint(attempt-1)
+	if backoff <= 0 || (d.policy.MaxDelay > 0 && backoff > d.policy.MaxDelay) {
+		backoff = d.policy.MaxDelay
+	}
+
+	// Full jitter within the upper half of the backoff
+	return backoff/2 + d.jitter(backoff/2)
+}
+
+func (d *retryDoer) jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return time.Duration(d.rand.Int63n(int64(max)))
+}
+
+func (p *RetryPolicy) idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch:
+		return p.RetryNonIdempotent
+	}
+
+	return true
+}
+
+func (p *RetryPolicy) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	for _, code := range p.StatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RetryAfter returns how long Contentful asked the client to wait before
+// sending another request, based on the X-Contentful-RateLimit-Reset and
+// Retry-After headers.
+func RetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	for _, header := range []string{"X-Contentful-RateLimit-Reset", "Retry-After"} {
+		value := resp.Header.Get(header)
+		if value == "" {
+			continue
+		}
+
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+
+		if date, err := http.ParseTime(value); err == nil {
+			wait := date.Sub(time.Now())
+			if wait < 0 {
+				wait = 0
+			}
+
+			return wait, true
+		}
+	}
+
+	return 0, false
+}
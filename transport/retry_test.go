@@ -0,0 +1,158 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+// sequenceDoer responds with the given status codes in order
+type sequenceDoer struct {
+	statuses []int
+	headers  http.Header
+	bodies   []string
+}
+
+func (d *sequenceDoer) Do(req *http.Request) (*http.Response, error) {
+	status := d.statuses[len(d.bodies)]
+
+	body := ""
+	if req.Body != nil {
+		data, _ := ioutil.ReadAll(req.Body)
+		body = string(data)
+	}
+
+	d.bodies = append(d.bodies, body)
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     d.headers,
+		Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+		Request:    req,
+	}, nil
+}
+
+func testPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+
+	return policy
+}
+
+func TestRetry(t *testing.T) {
+	doer := &sequenceDoer{statuses: []int{429, 503, 200}}
+	req, _ := http.NewRequest(http.MethodPut, "https://api.contentful.com/spaces/abc", bytes.NewBufferString(`{"name":"test"}`))
+
+	resp, err := Retry(doer, testPolicy()).Do(req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{`{"name":"test"}`, `{"name":"test"}`, `{"name":"test"}`}, doer.bodies, "Body should be sent with every attempt")
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	doer := &sequenceDoer{statuses: []int{500, 500, 500, 200}}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.contentful.com/spaces", nil)
+
+	policy := testPolicy()
+	policy.MaxAttempts = 3
+
+	resp, err := Retry(doer, policy).Do(req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Len(t, doer.bodies, 3)
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	doer := &sequenceDoer{statuses: []int{503, 200}}
+	req, _ := http.NewRequest(http.MethodPost, "https://api.contentful.com/spaces", bytes.NewBufferString("{}"))
+
+	resp, err := Retry(doer, testPolicy()).Do(req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Len(t, doer.bodies, 1, "POST requests should not be retried")
+
+	// Opt in
+	doer = &sequenceDoer{statuses: []int{503, 200}}
+	req, _ = http.NewRequest(http.MethodPost, "https://api.contentful.com/spaces", bytes.NewBufferString("{}"))
+
+	policy := testPolicy()
+	policy.RetryNonIdempotent = true
+
+	resp, err = Retry(doer, policy).Do(req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, doer.bodies, 2)
+}
+
+func TestRetryIgnoresClientErrors(t *testing.T) {
+	doer := &sequenceDoer{statuses: []int{404, 200}}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.contentful.com/spaces/abc", nil)
+
+	resp, err := Retry(doer, testPolicy()).Do(req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Len(t, doer.bodies, 1)
+}
+
+func TestRetryMaxElapsed(t *testing.T) {
+	doer := &sequenceDoer{
+		statuses: []int{429, 200},
+		headers:  http.Header{"X-Contentful-Ratelimit-Reset": []string{"60"}},
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.contentful.com/spaces", nil)
+
+	policy := testPolicy()
+	policy.MaxElapsed = time.Second
+
+	resp, err := Retry(doer, policy).Do(req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "Reset exceeding MaxElapsed should not be waited for")
+	assert.Len(t, doer.bodies, 1)
+}
+
+func TestRetryContextCancelled(t *testing.T) {
+	doer := &sequenceDoer{statuses: []int{503, 200}}
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequest(http.MethodGet, "https://api.contentful.com/spaces", nil)
+	req = req.WithContext(ctx)
+
+	policy := testPolicy()
+	policy.BaseDelay = time.Minute
+	policy.MaxDelay = time.Minute
+
+	go cancel()
+	_, err := Retry(doer, policy).Do(req)
+
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestRetryAfter(t *testing.T) {
+	_, ok := RetryAfter(nil)
+	assert.False(t, ok)
+
+	resp := &http.Response{Header: http.Header{}}
+	_, ok = RetryAfter(resp)
+	assert.False(t, ok)
+
+	resp.Header.Set("Retry-After", "3")
+	wait, ok := RetryAfter(resp)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	resp.Header.Set("X-Contentful-RateLimit-Reset", "7")
+	wait, ok = RetryAfter(resp)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, wait, "Contentful header should take precedence")
+}
@@ -0,0 +1,31 @@
+//go:build go1.21
+
+package transport
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogLogger adapts a *slog.Logger. Fields are logged as attributes and
+// records below the level enabled for the handler are dropped.
+func NewSlogLogger(logger *slog.Logger) StructuredLogger {
+	return &slogLogger{logger: logger}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l *slogLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	if !l.logger.Enabled(ctx, slog.Level(level)) {
+		return
+	}
+
+	attrs := make([]slog.Attr, len(fields))
+	for i, field := range fields {
+		attrs[i] = slog.Any(field.Key, field.Value)
+	}
+
+	l.logger.LogAttrs(ctx, slog.Level(level), msg, attrs...)
+}
@@ -0,0 +1,34 @@
+//go:build go1.21
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestSlogLogger(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	handler := slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := NewSlogLogger(slog.New(handler))
+
+	req, _ := http.NewRequest("GET", "https://cdn.contentful.com/spaces/abc", nil)
+	resp := &http.Response{StatusCode: 200, Header: http.Header{"X-Contentful-Request-Id": []string{"req-1"}}}
+	LogRequest(context.Background(), logger, req, resp, time.Millisecond, nil)
+
+	line := buffer.String()
+	assert.True(t, strings.Contains(line, `level=DEBUG msg="contentful request" method=GET path=/spaces/abc status=200 duration=1ms request_id=req-1`), line)
+
+	// Debug records are dropped by handlers at info level
+	buffer.Reset()
+	logger = NewSlogLogger(slog.New(slog.NewTextHandler(buffer, nil)))
+	LogRequest(context.Background(), logger, req, resp, time.Millisecond, nil)
+	assert.Empty(t, buffer.String())
+}
@@ -0,0 +1,14 @@
+// Package transport contains the HTTP plumbing shared by the delivery and
+// management clients.
+package transport
+
+import (
+	"net/http"
+)
+
+// Doer executes http requests.  It is implemented by *http.Client.  You can
+// wrap *http.Client with layers of Doers to form a stack of client-side
+// middleware.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// maxBodySize limits the size of webhook request bodies
+const maxBodySize = 10 << 20
+
+// HandlerFunc processes a webhook event. Returning an error responds with a
+// server error, which makes Contentful retry the webhook.
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// Handler is a http.Handler that verifies and parses webhook requests and
+// passes the resulting events to a HandlerFunc.
+type Handler struct {
+	// Secret is the signing secret of the webhook. Requests are not verified
+	// if it is empty.
+	Secret string
+	// TTL is how old a signed request may be. NewHandler sets it to DefaultTTL,
+	// zero disables the check.
+	TTL time.Duration
+
+	handle HandlerFunc
+}
+
+// NewHandler creates a handler that verifies requests with secret before
+// passing them to handle. Pass an empty secret to accept unsigned requests.
+func NewHandler(secret string, handle HandlerFunc) *Handler {
+	return &Handler{
+		Secret: secret,
+		TTL:    DefaultTTL,
+		handle: handle,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.Secret != "" {
+		if err = Verify(r, body, h.Secret, h.TTL); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	event, err := parse(r.Header, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = h.handle(r.Context(), event); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers used for signed webhook requests
+const (
+	SignatureHeader     = "X-Contentful-Signature"
+	SignedHeadersHeader = "X-Contentful-Signed-Headers"
+	TimestampHeader     = "X-Contentful-Timestamp"
+)
+
+// DefaultTTL is how old a signed request may be before it is rejected
+const DefaultTTL = 30 * time.Second
+
+// Errors returned when verifying signed requests
+var (
+	ErrMissingSignature = errors.New("webhook request is not signed")
+	ErrInvalidSignature = errors.New("webhook signature is invalid")
+	ErrExpired          = errors.New("webhook request has expired")
+)
+
+// Verify checks that the request was signed by Contentful with the secret of
+// the webhook and was sent no longer than ttl ago. A ttl of zero disables the
+// expiry check. body is the request body, which must have been read by the
+// caller.
+func Verify(r *http.Request, body []byte, secret string, ttl time.Duration) error {
+	signature := r.Header.Get(SignatureHeader)
+	signedHeaders := r.Header.Get(SignedHeadersHeader)
+	if signature == "" || signedHeaders == "" {
+		return ErrMissingSignature
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	names := strings.Split(signedHeaders, ",")
+	if !hmac.Equal(expected, sign(secret, r.Method, r.URL.RequestURI(), r.Header, names, body)) {
+		return ErrInvalidSignature
+	}
+
+	if ttl > 0 {
+		millis, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+
+		sent := time.Unix(0, millis*int64(time.Millisecond))
+		if time.Since(sent) > ttl {
+			return ErrExpired
+		}
+	}
+
+	return nil
+}
+
+// Sign adds the signature headers Contentful would send to the request. It is
+// useful to test webhook receivers.
+func Sign(r *http.Request, body []byte, secret string, now time.Time) {
+	r.Header.Set(TimestampHeader, fmt.Sprintf("%v", now.UnixNano()/int64(time.Millisecond)))
+
+	names := []string{strings.ToLower(TimestampHeader), strings.ToLower(SignedHeadersHeader)}
+	r.Header.Set(SignedHeadersHeader, strings.Join(names, ","))
+
+	signature := sign(secret, r.Method, r.URL.RequestURI(), r.Header, names, body)
+	r.Header.Set(SignatureHeader, hex.EncodeToString(signature))
+}
+
+// sign computes the HMAC-SHA256 of the canonical representation of the
+// request: the method, path, signed headers and body separated by newlines.
+func sign(secret string, method string, path string, header http.Header, names []string, body []byte) []byte {
+	headers := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		headers = append(headers, fmt.Sprintf("%v:%v", name, strings.TrimSpace(header.Get(name))))
+	}
+
+	canonical := strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		strings.Join(headers, ";"),
+		string(body),
+	}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(canonical))
+
+	return mac.Sum(nil)
+}
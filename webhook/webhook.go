@@ -0,0 +1,122 @@
+// Package webhook receives webhook requests sent by Contentful and decodes them
+// into typed events.
+//
+//	handler := webhook.NewHandler(secret, func(ctx context.Context, event *webhook.Event) error {
+//		if event.Topic.Action == webhook.ActionPublish && event.Entry != nil {
+//			return reindex(ctx, event.Entry)
+//		}
+//		return nil
+//	})
+//	http.Handle("/contentful", handler)
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/illyabusigin/contentful/models"
+)
+
+// TopicHeader is the header containing the topic of a webhook request
+const TopicHeader = "X-Contentful-Topic"
+
+// Actions that trigger webhooks
+const (
+	ActionCreate    = "create"
+	ActionSave      = "save"
+	ActionAutoSave  = "auto_save"
+	ActionArchive   = "archive"
+	ActionUnarchive = "unarchive"
+	ActionPublish   = "publish"
+	ActionUnpublish = "unpublish"
+	ActionDelete    = "delete"
+)
+
+// Topic identifies the kind of event, for example
+// "ContentManagement.Entry.publish".
+type Topic struct {
+	// Origin is the API that triggered the event, usually "ContentManagement"
+	Origin string
+	// Type is the type of the resource, for example "Entry" or "Asset"
+	Type string
+	// Action is what happened to the resource, for example "publish"
+	Action string
+}
+
+// ParseTopic parses the value of the X-Contentful-Topic header
+func ParseTopic(topic string) (Topic, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return Topic{}, fmt.Errorf("ParseTopic failed. %q is not a valid topic!", topic)
+	}
+
+	return Topic{Origin: parts[0], Type: parts[1], Action: parts[2]}, nil
+}
+
+func (t Topic) String() string {
+	return fmt.Sprintf("%v.%v.%v", t.Origin, t.Type, t.Action)
+}
+
+// Event is a webhook request sent by Contentful. Depending on the type of the
+// topic one of Entry, Asset or ContentType is set. Unpublish and delete events
+// only carry the sys properties of the resource.
+type Event struct {
+	Topic Topic
+
+	Entry       *Entry
+	Asset       *Asset
+	ContentType *ContentType
+
+	// Payload is the raw request body, it can be used to decode events of
+	// other resource types
+	Payload json.RawMessage
+	Header  http.Header
+}
+
+// Parse reads the webhook request into an event. The signature of the request
+// is not verified, use Verify or the Handler to do so.
+func Parse(r *http.Request) (*Event, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(r.Header, body)
+}
+
+func parse(header http.Header, body []byte) (*Event, error) {
+	topic, err := ParseTopic(header.Get(TopicHeader))
+	if err != nil {
+		return nil, err
+	}
+
+	event := &Event{
+		Topic:   topic,
+		Payload: json.RawMessage(body),
+		Header:  header,
+	}
+
+	var payload interface{}
+	switch topic.Type {
+	case "Entry":
+		event.Entry = new(Entry)
+		payload = event.Entry
+	case "Asset":
+		event.Asset = new(Asset)
+		payload = event.Asset
+	case "ContentType":
+		event.ContentType = new(ContentType)
+		payload = event.ContentType
+	default:
+		return event, nil
+	}
+
+	if err = json.Unmarshal(body, payload); err != nil {
+		return nil, fmt.Errorf("Parse failed. Unable to decode %v payload: %v", topic.Type, err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+const entryPayload = `{
+	"sys": {
+		"id": "entry123",
+		"type": "Entry",
+		"version": 3,
+		"space": {"sys": {"type": "Link", "linkType": "Space", "id": "space123"}},
+		"contentType": {"sys": {"type": "Link", "linkType": "ContentType", "id": "post"}}
+	},
+	"fields": {"title": {"en-US": "Hello"}}
+}`
+
+func newRequest(topic string, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/contentful?source=cms", bytes.NewBufferString(body))
+	req.Header.Set(TopicHeader, topic)
+	req.Header.Set("Content-Type", "application/vnd.contentful.management.v1+json")
+
+	return req
+}
+
+func TestParseTopic(t *testing.T) {
+	topic, err := ParseTopic("ContentManagement.Entry.publish")
+
+	assert.Nil(t, err)
+	assert.Equal(t, Topic{Origin: "ContentManagement", Type: "Entry", Action: ActionPublish}, topic)
+	assert.Equal(t, "ContentManagement.Entry.publish", topic.String())
+
+	_, err = ParseTopic("Entry.publish")
+	assert.NotNil(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	var received *Event
+	handler := NewHandler("secret", func(ctx context.Context, event *Event) error {
+		received = event
+		return nil
+	})
+
+	req := newRequest("ContentManagement.Entry.publish", entryPayload)
+	Sign(req, []byte(entryPayload), "secret", time.Now())
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotNil(t, received)
+	assert.Equal(t, ActionPublish, received.Topic.Action)
+	assert.Equal(t, "entry123", received.Entry.ID)
+	assert.Equal(t, "post", received.Entry.ContentType.ID)
+	assert.Equal(t, map[string]interface{}{"en-US": "Hello"}, received.Entry.Fields["title"])
+	assert.Nil(t, received.Asset)
+}
+
+func TestHandlerRejectsInvalidSignatures(t *testing.T) {
+	handler := NewHandler("secret", func(ctx context.Context, event *Event) error {
+		t.Fatal("Handler should not be called")
+		return nil
+	})
+
+	// Unsigned
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("ContentManagement.Entry.publish", entryPayload))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Wrong secret
+	req := newRequest("ContentManagement.Entry.publish", entryPayload)
+	Sign(req, []byte(entryPayload), "other", time.Now())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Tampered body
+	req = newRequest("ContentManagement.Entry.publish", `{"sys":{"id":"other"}}`)
+	Sign(req, []byte(entryPayload), "secret", time.Now())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Expired
+	req = newRequest("ContentManagement.Entry.publish", entryPayload)
+	Sign(req, []byte(entryPayload), "secret", time.Now().Add(-time.Minute))
+
+	err := Verify(req, []byte(entryPayload), "secret", DefaultTTL)
+	assert.Equal(t, ErrExpired, err)
+}
+
+func TestHandlerErrors(t *testing.T) {
+	handler := NewHandler("", func(ctx context.Context, event *Event) error {
+		return errors.New("unavailable")
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("", entryPayload))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Requests without topic should be rejected")
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("ContentManagement.Entry.publish", entryPayload))
+	assert.Equal(t, http.StatusInternalServerError, w.Code, "Handler errors should be retried by Contentful")
+}